@@ -0,0 +1,288 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/bborbe/errors"
+)
+
+// ErrBiMapConflict is returned when an entry of a BiMap would break its one-to-one mapping.
+var ErrBiMapConflict = stderrors.New("bimap conflict")
+
+// BiMap represents a thread-safe one-to-one mapping between keys and values.
+// Every value belongs to exactly one key, so entries can be looked up in both directions.
+//
+// Set never breaks an existing mapping: it returns an error wrapping ErrBiMapConflict if the
+// key or the value is already mapped to something else. ForceSet removes the conflicting
+// entries instead.
+type BiMap[K comparable, V comparable] interface {
+	// Get returns the value mapped to key and whether it was found.
+	Get(key K) (V, bool)
+	// GetKey returns the key mapped to value and whether it was found.
+	GetKey(value V) (K, bool)
+	// Set maps key to value. It returns an error wrapping ErrBiMapConflict and leaves the
+	// map unchanged if key is mapped to another value or value to another key.
+	Set(key K, value V) error
+	// ForceSet maps key to value, removing any entries of key or value before.
+	ForceSet(key K, value V)
+	// Delete removes the entries of keys. Missing keys are ignored.
+	Delete(keys ...K)
+	// DeleteValue removes the entries of values. Missing values are ignored.
+	DeleteValue(values ...V)
+	// Keys returns a new Set containing all keys.
+	Keys() Set[K]
+	// Values returns a new Set containing all values.
+	Values() Set[V]
+	// Length returns the number of entries in the map.
+	Length() int
+	// Each calls fn for each entry in the map. Iteration stops on first error.
+	// The order of iteration is arbitrary and not guaranteed to be consistent.
+	// fn may access and change the map; changes are not reflected in the iteration.
+	Each(ctx context.Context, fn func(ctx context.Context, key K, value V) error) error
+	// Inverse returns a view of the map from values to keys.
+	// The view shares its entries with the map, so changes to either are visible in both.
+	Inverse() BiMap[V, K]
+	// Clone returns a new BiMap containing all entries of the current map.
+	Clone() BiMap[K, V]
+	// String returns a human-readable representation in the form "BiMap[a:1, b:2]".
+	String() string
+	// UnmarshalJSON replaces the content with a JSON object mapping keys to values.
+	// It returns an error wrapping ErrBiMapConflict if two keys have the same value.
+	UnmarshalJSON(data []byte) error
+	// MarshalJSON writes the map as a JSON object mapping keys to values.
+	MarshalJSON() ([]byte, error)
+}
+
+// NewBiMap creates a new empty thread-safe one-to-one map.
+//
+// Example:
+//
+//	names := collection.NewBiMap[int, string]()
+//	err := names.Set(1, "alice")
+//	id, found := names.GetKey("alice") // 1, true
+func NewBiMap[K comparable, V comparable]() BiMap[K, V] {
+	return newBiMap[K, V]()
+}
+
+func newBiMap[K comparable, V comparable]() *biMap[K, V] {
+	return &biMap[K, V]{
+		mux:      &sync.Mutex{},
+		forward:  make(map[K]V),
+		backward: make(map[V]K),
+	}
+}
+
+// biMap keeps both directions in maps guarded by one mutex.
+// An inverse view shares the mutex and the maps with swapped roles.
+type biMap[K comparable, V comparable] struct {
+	mux      *sync.Mutex
+	forward  map[K]V
+	backward map[V]K
+}
+
+func (m *biMap[K, V]) Get(key K) (V, bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	value, found := m.forward[key]
+	return value, found
+}
+
+func (m *biMap[K, V]) GetKey(value V) (K, bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	key, found := m.backward[value]
+	return key, found
+}
+
+func (m *biMap[K, V]) Set(key K, value V) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if current, found := m.forward[key]; found && current != value {
+		return errors.Wrapf(
+			context.Background(),
+			ErrBiMapConflict,
+			"key %v already mapped to value %v",
+			key,
+			current,
+		)
+	}
+	if current, found := m.backward[value]; found && current != key {
+		return errors.Wrapf(
+			context.Background(),
+			ErrBiMapConflict,
+			"value %v already mapped to key %v",
+			value,
+			current,
+		)
+	}
+	m.forward[key] = value
+	m.backward[value] = key
+	return nil
+}
+
+func (m *biMap[K, V]) ForceSet(key K, value V) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.deleteLocked(key)
+	if current, found := m.backward[value]; found {
+		m.deleteLocked(current)
+	}
+	m.forward[key] = value
+	m.backward[value] = key
+}
+
+func (m *biMap[K, V]) Delete(keys ...K) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for _, key := range keys {
+		m.deleteLocked(key)
+	}
+}
+
+func (m *biMap[K, V]) DeleteValue(values ...V) {
+	m.Inverse().Delete(values...)
+}
+
+// deleteLocked removes the entry of key in both directions.
+func (m *biMap[K, V]) deleteLocked(key K) {
+	if value, found := m.forward[key]; found {
+		delete(m.forward, key)
+		delete(m.backward, value)
+	}
+}
+
+func (m *biMap[K, V]) Keys() Set[K] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return NewSet(slices.Collect(maps.Keys(m.forward))...)
+}
+
+func (m *biMap[K, V]) Values() Set[V] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return NewSet(slices.Collect(maps.Keys(m.backward))...)
+}
+
+func (m *biMap[K, V]) Length() int {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return len(m.forward)
+}
+
+// Each calls fn for each entry in the map. Iteration stops on first error.
+// The order of iteration is arbitrary and not guaranteed to be consistent.
+// fn may access and change the map; changes are not reflected in the iteration.
+func (m *biMap[K, V]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, key K, value V) error,
+) error {
+	m.mux.Lock()
+	keys := make([]K, 0, len(m.forward))
+	values := make([]V, 0, len(m.forward))
+	for key, value := range m.forward {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	m.mux.Unlock()
+
+	return eachPair(ctx, keys, values, fn)
+}
+
+// Inverse returns a view of the map from values to keys.
+// The view shares its entries with the map, so changes to either are visible in both.
+func (m *biMap[K, V]) Inverse() BiMap[V, K] {
+	return &biMap[V, K]{
+		mux:      m.mux,
+		forward:  m.backward,
+		backward: m.forward,
+	}
+}
+
+// Clone returns a new BiMap containing all entries of the current map.
+// The returned map is a shallow copy - modifications to it won't affect the original.
+func (m *biMap[K, V]) Clone() BiMap[K, V] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return &biMap[K, V]{
+		mux:      &sync.Mutex{},
+		forward:  maps.Clone(m.forward),
+		backward: maps.Clone(m.backward),
+	}
+}
+
+// String returns a human-readable string representation of the map.
+// Format: "BiMap[key1:value1, key2:value2, ...]" sorted by the string representation.
+func (m *biMap[K, V]) String() string {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	result := make([]string, 0, len(m.forward))
+	for key, value := range m.forward {
+		result = append(result, elementToString(key)+":"+elementToString(value))
+	}
+	sort.Strings(result)
+	return formatSetString("BiMap[", result)
+}
+
+// MarshalJSON implements json.Marshaler for BiMap.
+// It serializes the map as a JSON object, so K must be a string or integer type
+// or implement encoding.TextMarshaler.
+func (m *biMap[K, V]) MarshalJSON() ([]byte, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return json.Marshal(m.forward)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for BiMap.
+// It replaces the content of the map with the entries of a JSON object.
+// The map is left unchanged if two keys have the same value.
+func (m *biMap[K, V]) UnmarshalJSON(data []byte) error {
+	var entries map[K]V
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	backward := make(map[V]K, len(entries))
+	for key, value := range entries {
+		if current, found := backward[value]; found {
+			return errors.Wrapf(
+				context.Background(),
+				ErrBiMapConflict,
+				"value %v mapped to keys %v and %v",
+				value,
+				current,
+				key,
+			)
+		}
+		backward[value] = key
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	// the maps are refilled in place because an inverse view may share them
+	clear(m.forward)
+	clear(m.backward)
+	maps.Copy(m.forward, entries)
+	maps.Copy(m.backward, backward)
+	return nil
+}
@@ -0,0 +1,95 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("BiMap", func() {
+	var m collection.BiMap[int, string]
+	BeforeEach(func() {
+		m = collection.NewBiMap[int, string]()
+		Expect(m.Set(1, "a")).To(Succeed())
+		Expect(m.Set(2, "b")).To(Succeed())
+	})
+	It("looks up in both directions", func() {
+		Expect(mustFind(m.Get(1))).To(Equal("a"))
+		Expect(mustFind(m.GetKey("b"))).To(Equal(2))
+		_, found := m.GetKey("c")
+		Expect(found).To(BeFalse())
+		Expect(m.Length()).To(Equal(2))
+		Expect(m.Values().Contains("a")).To(BeTrue())
+		Expect(m.Keys().Contains(2)).To(BeTrue())
+	})
+	It("rejects conflicting entries", func() {
+		Expect(m.Set(1, "a")).To(Succeed())
+		err := m.Set(1, "c")
+		Expect(errors.Is(err, collection.ErrBiMapConflict)).To(BeTrue())
+		err = m.Set(3, "a")
+		Expect(errors.Is(err, collection.ErrBiMapConflict)).To(BeTrue())
+		Expect(m.String()).To(Equal("BiMap[1:a, 2:b]"))
+	})
+	It("replaces conflicting entries with ForceSet", func() {
+		m.ForceSet(1, "b")
+		Expect(m.String()).To(Equal("BiMap[1:b]"))
+		_, found := m.GetKey("a")
+		Expect(found).To(BeFalse())
+	})
+	It("deletes by key and value", func() {
+		m.Delete(1)
+		m.DeleteValue("b", "x")
+		Expect(m.Length()).To(Equal(0))
+		_, found := m.GetKey("a")
+		Expect(found).To(BeFalse())
+	})
+	It("shares entries with the inverse view", func() {
+		inverse := m.Inverse()
+		Expect(mustFind(inverse.Get("a"))).To(Equal(1))
+		Expect(inverse.Set("c", 3)).To(Succeed())
+		Expect(mustFind(m.Get(3))).To(Equal("c"))
+		m.Delete(1)
+		Expect(inverse.String()).To(Equal("BiMap[b:2, c:3]"))
+	})
+	It("clones without affecting the original", func() {
+		clone := m.Clone()
+		clone.Delete(1)
+		Expect(m.Length()).To(Equal(2))
+		Expect(clone.Length()).To(Equal(1))
+	})
+	It("iterates all entries", func() {
+		var values []string
+		err := m.Each(context.Background(), func(ctx context.Context, key int, value string) error {
+			values = append(values, value)
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		sort.Strings(values)
+		Expect(values).To(Equal([]string{"a", "b"}))
+	})
+	It("round-trips json", func() {
+		data, err := json.Marshal(m)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"1":"a","2":"b"}`))
+
+		decoded := collection.NewBiMap[int, string]()
+		inverse := decoded.Inverse()
+		Expect(json.Unmarshal(data, decoded)).To(Succeed())
+		Expect(mustFind(inverse.Get("b"))).To(Equal(2))
+	})
+	It("rejects json with duplicate values", func() {
+		err := json.Unmarshal([]byte(`{"3":"c","4":"c"}`), m)
+		Expect(errors.Is(err, collection.ErrBiMapConflict)).To(BeTrue())
+		Expect(m.Length()).To(Equal(2))
+	})
+})
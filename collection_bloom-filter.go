@@ -0,0 +1,475 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sync"
+
+	"github.com/bborbe/errors"
+)
+
+// BloomFilter represents a thread-safe probabilistic set. MayContain never returns false
+// for added elements, but may return true for elements that were never added with
+// the false-positive rate the filter was sized for.
+//
+// Elements are hashed from a stable byte representation (encoding.BinaryMarshaler,
+// built-in encoding of string, bool, int, uint and float types, or JSON otherwise),
+// so filters can be saved with MarshalBinary and merged between runs.
+type BloomFilter[T comparable] interface {
+	// Add inserts elements into the filter.
+	Add(elements ...T)
+	// MayContain reports whether element may have been added.
+	// A false result means the element was definitely not added.
+	MayContain(element T) bool
+	// Size returns the number of bits of the filter.
+	Size() uint64
+	// HashCount returns the number of hash functions per element.
+	HashCount() uint64
+	// Union returns a new filter containing the elements of both filters.
+	// It returns an error if the filters have different parameters.
+	Union(other BloomFilter[T]) (BloomFilter[T], error)
+	// Clone returns a copy of the filter.
+	Clone() BloomFilter[T]
+	// MarshalBinary implements encoding.BinaryMarshaler.
+	MarshalBinary() ([]byte, error)
+	// UnmarshalBinary implements encoding.BinaryUnmarshaler and replaces
+	// the parameters and content of the filter.
+	UnmarshalBinary(data []byte) error
+}
+
+// NewBloomFilter creates a new thread-safe Bloom filter sized to hold expectedItems elements
+// with the given false-positive rate. It returns an error if expectedItems is below 1 or
+// falsePositiveRate is not between 0 and 1.
+//
+// Example:
+//
+//	seen, err := collection.NewBloomFilter[string](1_000_000, 0.01)
+//	if !seen.MayContain(key) {
+//		seen.Add(key)
+//	}
+func NewBloomFilter[T comparable](
+	expectedItems int,
+	falsePositiveRate float64,
+) (BloomFilter[T], error) {
+	params, err := newBloomParams(expectedItems, falsePositiveRate)
+	if err != nil {
+		return nil, err
+	}
+	return newBloomFilter[T](params), nil
+}
+
+func newBloomFilter[T comparable](params bloomParams) *bloomFilter[T] {
+	return &bloomFilter[T]{
+		params: params,
+		bits:   make([]uint64, (params.m+63)/64),
+		key:    stableKeyFunc[T](),
+	}
+}
+
+type bloomFilter[T comparable] struct {
+	mux    sync.Mutex
+	params bloomParams
+	bits   []uint64
+	key    func(element T) []byte
+}
+
+func (f *bloomFilter[T]) Add(elements ...T) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	for _, element := range elements {
+		f.params.locations(f.key(element), func(index uint64) bool {
+			f.bits[index/64] |= 1 << (index % 64)
+			return true
+		})
+	}
+}
+
+func (f *bloomFilter[T]) MayContain(element T) bool {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	return f.params.locations(f.key(element), func(index uint64) bool {
+		return f.bits[index/64]&(1<<(index%64)) != 0
+	})
+}
+
+func (f *bloomFilter[T]) Size() uint64 {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	return f.params.m
+}
+
+func (f *bloomFilter[T]) HashCount() uint64 {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	return f.params.k
+}
+
+// Union returns a new filter containing the elements of both filters.
+// It returns an error if the filters have different parameters.
+func (f *bloomFilter[T]) Union(other BloomFilter[T]) (BloomFilter[T], error) {
+	data, err := other.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrapf(context.Background(), err, "marshal other filter failed")
+	}
+	var decoded bloomFilter[T]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	result := f.Clone().(*bloomFilter[T])
+	if result.params != decoded.params {
+		return nil, errors.Errorf(
+			context.Background(),
+			"union of filters with different parameters %v and %v",
+			result.params,
+			decoded.params,
+		)
+	}
+	for i, word := range decoded.bits {
+		result.bits[i] |= word
+	}
+	return result, nil
+}
+
+// Clone returns a copy of the filter.
+func (f *bloomFilter[T]) Clone() BloomFilter[T] {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	result := newBloomFilter[T](f.params)
+	copy(result.bits, f.bits)
+	return result
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler for BloomFilter.
+func (f *bloomFilter[T]) MarshalBinary() ([]byte, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	buf := f.params.appendHeader(nil, bloomBinaryBits)
+	for _, word := range f.bits {
+		buf = binary.LittleEndian.AppendUint64(buf, word)
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for BloomFilter.
+// The filter is left unchanged if data is invalid.
+func (f *bloomFilter[T]) UnmarshalBinary(data []byte) error {
+	params, payload, err := parseBloomHeader(data, bloomBinaryBits)
+	if err != nil {
+		return err
+	}
+	words := (params.m + 63) / 64
+	if uint64(len(payload)) != words*8 {
+		return errors.Errorf(context.Background(), "invalid bloom filter length %d", len(payload))
+	}
+	bits := make([]uint64, words)
+	for i := range bits {
+		bits[i] = binary.LittleEndian.Uint64(payload[i*8:])
+	}
+
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	f.params = params
+	f.bits = bits
+	if f.key == nil {
+		f.key = stableKeyFunc[T]()
+	}
+	return nil
+}
+
+// CountingBloomFilter represents a thread-safe Bloom filter with a counter per position,
+// so elements can be removed again. Counters saturate at 255 and are never decremented
+// afterwards. Removing an element that was never added can cause false negatives.
+type CountingBloomFilter[T comparable] interface {
+	// Add inserts elements into the filter.
+	Add(elements ...T)
+	// Remove deletes elements from the filter. Elements that are definitely not
+	// part of the filter are ignored.
+	Remove(elements ...T)
+	// MayContain reports whether element may have been added.
+	// A false result means the element was definitely not added.
+	MayContain(element T) bool
+	// Size returns the number of counters of the filter.
+	Size() uint64
+	// HashCount returns the number of hash functions per element.
+	HashCount() uint64
+	// Union returns a new filter with the counters of both filters added.
+	// It returns an error if the filters have different parameters.
+	Union(other CountingBloomFilter[T]) (CountingBloomFilter[T], error)
+	// Clone returns a copy of the filter.
+	Clone() CountingBloomFilter[T]
+	// MarshalBinary implements encoding.BinaryMarshaler.
+	MarshalBinary() ([]byte, error)
+	// UnmarshalBinary implements encoding.BinaryUnmarshaler and replaces
+	// the parameters and content of the filter.
+	UnmarshalBinary(data []byte) error
+}
+
+// NewCountingBloomFilter creates a new thread-safe counting Bloom filter sized to hold
+// expectedItems elements with the given false-positive rate. It uses one byte per counter,
+// eight times the memory of a BloomFilter with the same parameters.
+func NewCountingBloomFilter[T comparable](
+	expectedItems int,
+	falsePositiveRate float64,
+) (CountingBloomFilter[T], error) {
+	params, err := newBloomParams(expectedItems, falsePositiveRate)
+	if err != nil {
+		return nil, err
+	}
+	return newCountingBloomFilter[T](params), nil
+}
+
+func newCountingBloomFilter[T comparable](params bloomParams) *countingBloomFilter[T] {
+	return &countingBloomFilter[T]{
+		params:   params,
+		counters: make([]uint8, params.m),
+		key:      stableKeyFunc[T](),
+	}
+}
+
+type countingBloomFilter[T comparable] struct {
+	mux      sync.Mutex
+	params   bloomParams
+	counters []uint8
+	key      func(element T) []byte
+}
+
+func (f *countingBloomFilter[T]) Add(elements ...T) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	for _, element := range elements {
+		f.params.locations(f.key(element), func(index uint64) bool {
+			if f.counters[index] < math.MaxUint8 {
+				f.counters[index]++
+			}
+			return true
+		})
+	}
+}
+
+func (f *countingBloomFilter[T]) Remove(elements ...T) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	for _, element := range elements {
+		key := f.key(element)
+		if !f.mayContain(key) {
+			continue
+		}
+		f.params.locations(key, func(index uint64) bool {
+			if f.counters[index] < math.MaxUint8 {
+				f.counters[index]--
+			}
+			return true
+		})
+	}
+}
+
+func (f *countingBloomFilter[T]) MayContain(element T) bool {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	return f.mayContain(f.key(element))
+}
+
+func (f *countingBloomFilter[T]) mayContain(key []byte) bool {
+	return f.params.locations(key, func(index uint64) bool {
+		return f.counters[index] > 0
+	})
+}
+
+func (f *countingBloomFilter[T]) Size() uint64 {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	return f.params.m
+}
+
+func (f *countingBloomFilter[T]) HashCount() uint64 {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	return f.params.k
+}
+
+// Union returns a new filter with the counters of both filters added.
+// It returns an error if the filters have different parameters.
+func (f *countingBloomFilter[T]) Union(
+	other CountingBloomFilter[T],
+) (CountingBloomFilter[T], error) {
+	data, err := other.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrapf(context.Background(), err, "marshal other filter failed")
+	}
+	var decoded countingBloomFilter[T]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	result := f.Clone().(*countingBloomFilter[T])
+	if result.params != decoded.params {
+		return nil, errors.Errorf(
+			context.Background(),
+			"union of filters with different parameters %v and %v",
+			result.params,
+			decoded.params,
+		)
+	}
+	for i, counter := range decoded.counters {
+		if counter > math.MaxUint8-result.counters[i] {
+			result.counters[i] = math.MaxUint8
+		} else {
+			result.counters[i] += counter
+		}
+	}
+	return result, nil
+}
+
+// Clone returns a copy of the filter.
+func (f *countingBloomFilter[T]) Clone() CountingBloomFilter[T] {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	result := newCountingBloomFilter[T](f.params)
+	copy(result.counters, f.counters)
+	return result
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler for CountingBloomFilter.
+func (f *countingBloomFilter[T]) MarshalBinary() ([]byte, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	return append(f.params.appendHeader(nil, bloomBinaryCounters), f.counters...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for CountingBloomFilter.
+// The filter is left unchanged if data is invalid.
+func (f *countingBloomFilter[T]) UnmarshalBinary(data []byte) error {
+	params, payload, err := parseBloomHeader(data, bloomBinaryCounters)
+	if err != nil {
+		return err
+	}
+	if uint64(len(payload)) != params.m {
+		return errors.Errorf(context.Background(), "invalid bloom filter length %d", len(payload))
+	}
+
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	f.params = params
+	f.counters = bytes.Clone(payload)
+	if f.key == nil {
+		f.key = stableKeyFunc[T]()
+	}
+	return nil
+}
+
+// The binary Bloom filter format is
+//
+//	version byte    bloomBinaryVersion
+//	kind    byte    bloomBinaryBits or bloomBinaryCounters
+//	m       uvarint number of bits or counters
+//	k       uvarint number of hash functions
+//
+// followed by m bits as little-endian uint64 words or m one-byte counters.
+const (
+	bloomBinaryVersion  byte = 1
+	bloomBinaryBits     byte = 1
+	bloomBinaryCounters byte = 2
+
+	// bloomMaxHashCount bounds k for corrupt input.
+	bloomMaxHashCount = 64
+)
+
+// bloomParams are the number of bits or counters m and the number of hash functions k.
+type bloomParams struct {
+	m uint64
+	k uint64
+}
+
+// newBloomParams calculates the optimal parameters for n elements and false-positive rate p.
+func newBloomParams(n int, p float64) (bloomParams, error) {
+	ctx := context.Background()
+	if n < 1 {
+		return bloomParams{}, errors.Errorf(ctx, "expected items %d must be at least 1", n)
+	}
+	if p <= 0 || p >= 1 {
+		return bloomParams{}, errors.Errorf(ctx, "false-positive rate %v must be in (0, 1)", p)
+	}
+	m := math.Ceil(-float64(n) * math.Log(p) / (math.Ln2 * math.Ln2))
+	k := math.Round(m / float64(n) * math.Ln2)
+	return bloomParams{
+		m: uint64(m),
+		k: uint64(min(max(k, 1), bloomMaxHashCount)),
+	}, nil
+}
+
+// locations calls fn for the k positions of key until fn returns false.
+// It uses double hashing, deriving all positions from one 64-bit FNV-1a hash.
+func (p bloomParams) locations(key []byte, fn func(index uint64) bool) bool {
+	h1 := fnv64a(key)
+	h2 := splitmix64(h1) | 1
+	for i := uint64(0); i < p.k; i++ {
+		if !fn((h1 + i*h2) % p.m) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p bloomParams) String() string {
+	return fmt.Sprintf("m=%d k=%d", p.m, p.k)
+}
+
+func (p bloomParams) appendHeader(buf []byte, kind byte) []byte {
+	buf = append(buf, bloomBinaryVersion, kind)
+	buf = binary.AppendUvarint(buf, p.m)
+	return binary.AppendUvarint(buf, p.k)
+}
+
+// parseBloomHeader reads the parameters of a binary Bloom filter and returns the payload.
+func parseBloomHeader(data []byte, kind byte) (bloomParams, []byte, error) {
+	ctx := context.Background()
+	if len(data) < 2 {
+		return bloomParams{}, nil, errors.New(ctx, "binary bloom filter data too short")
+	}
+	if data[0] != bloomBinaryVersion {
+		return bloomParams{}, nil, errors.Errorf(ctx, "unsupported version %d", data[0])
+	}
+	if data[1] != kind {
+		return bloomParams{}, nil, errors.Errorf(ctx, "unexpected bloom filter kind %d", data[1])
+	}
+	reader := bytes.NewReader(data[2:])
+	m, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return bloomParams{}, nil, errors.Wrapf(ctx, err, "read size failed")
+	}
+	k, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return bloomParams{}, nil, errors.Wrapf(ctx, err, "read hash count failed")
+	}
+	if m == 0 || k == 0 || k > bloomMaxHashCount {
+		return bloomParams{}, nil, errors.Errorf(ctx, "invalid parameters m=%d k=%d", m, k)
+	}
+	// Every kind stores at least one bit per position, so a larger m is corrupt and
+	// would overflow the computation of the payload length.
+	payload := data[len(data)-reader.Len():]
+	if m > uint64(len(payload))*8 {
+		return bloomParams{}, nil, errors.Errorf(ctx, "size m=%d exceeds data", m)
+	}
+	return bloomParams{m: m, k: k}, payload, nil
+}
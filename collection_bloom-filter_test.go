@@ -0,0 +1,146 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("BloomFilter", func() {
+	var filter collection.BloomFilter[string]
+	BeforeEach(func() {
+		filter = must(collection.NewBloomFilter[string](1000, 0.01))
+	})
+	It("is sized from expected items and false-positive rate", func() {
+		Expect(filter.Size()).To(Equal(uint64(9586)))
+		Expect(filter.HashCount()).To(Equal(uint64(7)))
+	})
+	DescribeTable("rejects invalid parameters",
+		func(expectedItems int, falsePositiveRate float64) {
+			_, err := collection.NewBloomFilter[string](expectedItems, falsePositiveRate)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("no items", 0, 0.01),
+		Entry("zero rate", 10, 0.0),
+		Entry("rate of one", 10, 1.0),
+	)
+	It("has no false negatives and few false positives", func() {
+		for i := 0; i < 1000; i++ {
+			filter.Add(strconv.Itoa(i))
+		}
+		for i := 0; i < 1000; i++ {
+			Expect(filter.MayContain(strconv.Itoa(i))).To(BeTrue())
+		}
+		var falsePositives int
+		for i := 1000; i < 11000; i++ {
+			if filter.MayContain(strconv.Itoa(i)) {
+				falsePositives++
+			}
+		}
+		Expect(falsePositives).To(BeNumerically("<", 200))
+	})
+	It("supports struct elements", func() {
+		users := must(collection.NewBloomFilter[User](10, 0.01))
+		users.Add(User{Firstname: "Alice", Age: 25})
+		Expect(users.MayContain(User{Firstname: "Alice", Age: 25})).To(BeTrue())
+		Expect(users.MayContain(User{Firstname: "Bob", Age: 30})).To(BeFalse())
+	})
+	It("unions filters with the same parameters", func() {
+		other := must(collection.NewBloomFilter[string](1000, 0.01))
+		filter.Add("a")
+		other.Add("b")
+		union := must(filter.Union(other))
+		Expect(union.MayContain("a")).To(BeTrue())
+		Expect(union.MayContain("b")).To(BeTrue())
+		Expect(filter.MayContain("b")).To(BeFalse())
+	})
+	It("rejects the union of filters with different parameters", func() {
+		other := must(collection.NewBloomFilter[string](10, 0.01))
+		_, err := filter.Union(other)
+		Expect(err).To(HaveOccurred())
+	})
+	It("round-trips binary", func() {
+		filter.Add("a", "b")
+		data := must(filter.MarshalBinary())
+
+		decoded := must(collection.NewBloomFilter[string](1, 0.5))
+		Expect(decoded.UnmarshalBinary(data)).To(Succeed())
+		Expect(decoded.Size()).To(Equal(filter.Size()))
+		Expect(decoded.MayContain("a")).To(BeTrue())
+		Expect(decoded.MayContain("b")).To(BeTrue())
+		Expect(decoded.MayContain("c")).To(BeFalse())
+		Expect(must(decoded.MarshalBinary())).To(Equal(data))
+	})
+	DescribeTable("rejects invalid binary data",
+		func(data []byte) {
+			Expect(filter.UnmarshalBinary(data)).NotTo(Succeed())
+			Expect(filter.Size()).To(Equal(uint64(9586)))
+		},
+		Entry("empty", []byte{}),
+		Entry("unknown version", []byte{99, 1, 64, 1}),
+		Entry("counting filter", []byte{1, 2, 1, 1, 0}),
+		Entry("zero hash functions", []byte{1, 1, 64, 0, 0, 0, 0, 0, 0, 0, 0, 0}),
+		Entry("truncated", []byte{1, 1, 64, 1, 0}),
+		Entry(
+			"overflowing size",
+			[]byte{1, 1, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 3},
+		),
+		Entry("size larger than data", []byte{1, 1, 0x80, 0x80, 0x04, 1, 0, 0, 0, 0, 0, 0, 0, 0}),
+	)
+})
+
+var _ = Describe("CountingBloomFilter", func() {
+	var filter collection.CountingBloomFilter[int]
+	BeforeEach(func() {
+		filter = must(collection.NewCountingBloomFilter[int](100, 0.01))
+	})
+	It("removes elements", func() {
+		filter.Add(1, 2, 2)
+		filter.Remove(2)
+		Expect(filter.MayContain(2)).To(BeTrue())
+		filter.Remove(1, 2)
+		Expect(filter.MayContain(1)).To(BeFalse())
+		Expect(filter.MayContain(2)).To(BeFalse())
+	})
+	It("ignores removing elements that are not contained", func() {
+		filter.Add(1)
+		filter.Remove(3)
+		Expect(filter.MayContain(1)).To(BeTrue())
+	})
+	It("unions counters", func() {
+		other := filter.Clone()
+		filter.Add(1)
+		other.Add(1)
+		union := must(filter.Union(other))
+		union.Remove(1)
+		Expect(union.MayContain(1)).To(BeTrue())
+		union.Remove(1)
+		Expect(union.MayContain(1)).To(BeFalse())
+	})
+	DescribeTable("rejects corrupt headers",
+		func(data []byte) {
+			Expect(filter.UnmarshalBinary(data)).NotTo(Succeed())
+			Expect(filter.MayContain(1)).To(BeFalse())
+		},
+		Entry(
+			"overflowing size",
+			[]byte{1, 2, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01, 3},
+		),
+		Entry("size larger than data", []byte{1, 2, 0x80, 0x01, 1, 0}),
+	)
+	It("round-trips binary", func() {
+		filter.Add(1, 2)
+		decoded := must(collection.NewCountingBloomFilter[int](1, 0.5))
+		Expect(decoded.UnmarshalBinary(must(filter.MarshalBinary()))).To(Succeed())
+		decoded.Remove(1)
+		Expect(decoded.MayContain(1)).To(BeFalse())
+		Expect(decoded.MayContain(2)).To(BeTrue())
+	})
+})
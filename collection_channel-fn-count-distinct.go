@@ -0,0 +1,34 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"context"
+
+	"github.com/bborbe/errors"
+)
+
+// ChannelFnCountDistinct executes a function that sends values to a channel and
+// returns the approximate number of distinct values sent. It uses a HyperLogLog with
+// HyperLogLogDefaultPrecision, so memory stays constant and the standard error is about 0.8%.
+// Returns -1 if an error occurs.
+func ChannelFnCountDistinct[T comparable](
+	ctx context.Context,
+	fn func(ctx context.Context, ch chan<- T) error,
+) (int, error) {
+	sketch := newHyperLogLog[T](HyperLogLogDefaultPrecision)
+	err := ChannelFnMap(
+		ctx,
+		fn,
+		func(ctx context.Context, t T) error {
+			sketch.Add(t)
+			return nil
+		},
+	)
+	if err != nil {
+		return -1, errors.Wrap(ctx, err, "count distinct channel failed")
+	}
+	return sketch.Count(), nil
+}
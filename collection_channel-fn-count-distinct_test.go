@@ -0,0 +1,61 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("ChannelFnCountDistinct", func() {
+	It("counts zero elements from empty channel", func() {
+		ctx := context.Background()
+		count, err := collection.ChannelFnCountDistinct(
+			ctx,
+			func(ctx context.Context, ch chan<- string) error {
+				return nil
+			},
+		)
+
+		Expect(err).To(BeNil())
+		Expect(count).To(Equal(0))
+	})
+
+	It("counts distinct elements", func() {
+		ctx := context.Background()
+		count, err := collection.ChannelFnCountDistinct(
+			ctx,
+			func(ctx context.Context, ch chan<- int) error {
+				for i := 0; i < 50000; i++ {
+					ch <- i % 10000
+				}
+				return nil
+			},
+		)
+
+		Expect(err).To(BeNil())
+		Expect(count).To(BeNumerically("~", 10000, 300))
+	})
+
+	It("handles error from producer function", func() {
+		ctx := context.Background()
+		count, err := collection.ChannelFnCountDistinct(
+			ctx,
+			func(ctx context.Context, ch chan<- string) error {
+				ch <- "before error"
+				return fmt.Errorf("producer error")
+			},
+		)
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("count distinct channel failed"))
+		Expect(count).To(Equal(-1))
+	})
+})
@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"container/heap"
+	"context"
+
+	"github.com/bborbe/errors"
+)
+
+// ChannelFnTopFrequent executes a function that sends values to a channel and
+// returns the n most frequent values with their approximate counts in descending order.
+// Counts are estimated with a CountMinSketch (epsilon 0.001, delta 0.01) and only n
+// candidates are kept, so memory stays constant regardless of the number of distinct values.
+func ChannelFnTopFrequent[T comparable](
+	ctx context.Context,
+	fn func(ctx context.Context, ch chan<- T) error,
+	n int,
+) ([]MultisetEntry[T], error) {
+	if n <= 0 {
+		return nil, errors.Errorf(ctx, "n %d must be at least 1", n)
+	}
+	sketch := newCountMinSketch[T](channelFnTopFrequentWidth, channelFnTopFrequentDepth)
+	candidates := newTopCandidates[T](n)
+	err := ChannelFnMap(
+		ctx,
+		fn,
+		func(ctx context.Context, t T) error {
+			sketch.Add(t)
+			candidates.update(t, sketch.Count(t))
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, errors.Wrap(ctx, err, "top frequent channel failed")
+	}
+	result := append([]MultisetEntry[T](nil), candidates.entries...)
+	sortEntriesByCount(result)
+	return result, nil
+}
+
+const (
+	// channelFnTopFrequentWidth is ceil(e/0.001).
+	channelFnTopFrequentWidth = 2719
+	// channelFnTopFrequentDepth is ceil(ln(1/0.01)).
+	channelFnTopFrequentDepth = 5
+)
+
+// topCandidates keeps the n elements with the highest counts in a min-heap indexed by
+// element, so updating a count or replacing the lowest candidate costs O(log n).
+type topCandidates[T comparable] struct {
+	n       int
+	entries []MultisetEntry[T]
+	index   map[T]int
+}
+
+func newTopCandidates[T comparable](n int) *topCandidates[T] {
+	return &topCandidates[T]{
+		n:       n,
+		entries: make([]MultisetEntry[T], 0, n),
+		index:   make(map[T]int, n),
+	}
+}
+
+// update records count for element if it is a candidate, or replaces the candidate
+// with the lowest count if there are n candidates and the lowest count is lower.
+func (c *topCandidates[T]) update(element T, count int) {
+	if i, found := c.index[element]; found {
+		c.entries[i].Count = count
+		heap.Fix(c, i)
+		return
+	}
+	if len(c.entries) < c.n {
+		heap.Push(c, MultisetEntry[T]{Element: element, Count: count})
+		return
+	}
+	if c.entries[0].Count < count {
+		delete(c.index, c.entries[0].Element)
+		c.entries[0] = MultisetEntry[T]{Element: element, Count: count}
+		c.index[element] = 0
+		heap.Fix(c, 0)
+	}
+}
+
+func (c *topCandidates[T]) Len() int {
+	return len(c.entries)
+}
+
+func (c *topCandidates[T]) Less(i, j int) bool {
+	return c.entries[i].Count < c.entries[j].Count
+}
+
+func (c *topCandidates[T]) Swap(i, j int) {
+	c.entries[i], c.entries[j] = c.entries[j], c.entries[i]
+	c.index[c.entries[i].Element] = i
+	c.index[c.entries[j].Element] = j
+}
+
+func (c *topCandidates[T]) Push(x any) {
+	entry := x.(MultisetEntry[T])
+	c.index[entry.Element] = len(c.entries)
+	c.entries = append(c.entries, entry)
+}
+
+func (c *topCandidates[T]) Pop() any {
+	n := len(c.entries)
+	entry := c.entries[n-1]
+	c.entries = c.entries[:n-1]
+	delete(c.index, entry.Element)
+	return entry
+}
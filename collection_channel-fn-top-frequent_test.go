@@ -0,0 +1,114 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("ChannelFnTopFrequent", func() {
+	It("returns the most frequent elements", func() {
+		ctx := context.Background()
+		top, err := collection.ChannelFnTopFrequent(
+			ctx,
+			func(ctx context.Context, ch chan<- string) error {
+				for i := 0; i < 1000; i++ {
+					ch <- fmt.Sprintf("rare-%d", i)
+					if i%2 == 0 {
+						ch <- "frequent"
+					}
+					if i%5 == 0 {
+						ch <- "common"
+					}
+				}
+				return nil
+			},
+			2,
+		)
+
+		Expect(err).To(BeNil())
+		Expect(top).To(HaveLen(2))
+		Expect(top[0].Element).To(Equal("frequent"))
+		Expect(top[0].Count).To(BeNumerically("~", 500, 10))
+		Expect(top[1].Element).To(Equal("common"))
+		Expect(top[1].Count).To(BeNumerically("~", 200, 10))
+	})
+
+	It("returns fewer elements if the channel has fewer distinct values", func() {
+		ctx := context.Background()
+		top, err := collection.ChannelFnTopFrequent(
+			ctx,
+			func(ctx context.Context, ch chan<- int) error {
+				ch <- 1
+				ch <- 2
+				ch <- 2
+				return nil
+			},
+			5,
+		)
+
+		Expect(err).To(BeNil())
+		Expect(top).To(Equal([]collection.MultisetEntry[int]{
+			{Element: 2, Count: 2},
+			{Element: 1, Count: 1},
+		}))
+	})
+
+	It("replaces candidates by elements that become frequent later", func() {
+		ctx := context.Background()
+		top, err := collection.ChannelFnTopFrequent(
+			ctx,
+			func(ctx context.Context, ch chan<- int) error {
+				for i := 0; i < 100; i++ {
+					ch <- i
+				}
+				for i := 0; i < 50; i++ {
+					ch <- 1000 + i%3
+				}
+				return nil
+			},
+			3,
+		)
+
+		Expect(err).To(BeNil())
+		Expect(top).To(HaveLen(3))
+		for _, entry := range top {
+			Expect(entry.Element).To(BeElementOf(1000, 1001, 1002))
+			Expect(entry.Count).To(BeNumerically(">=", 16))
+		}
+	})
+
+	It("rejects n below 1", func() {
+		_, err := collection.ChannelFnTopFrequent(
+			context.Background(),
+			func(ctx context.Context, ch chan<- int) error {
+				return nil
+			},
+			0,
+		)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("handles error from producer function", func() {
+		ctx := context.Background()
+		top, err := collection.ChannelFnTopFrequent(
+			ctx,
+			func(ctx context.Context, ch chan<- string) error {
+				return fmt.Errorf("producer error")
+			},
+			1,
+		)
+
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("top frequent channel failed"))
+		Expect(top).To(BeNil())
+	})
+})
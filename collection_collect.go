@@ -0,0 +1,25 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import "iter"
+
+// Collect consumes the given iterator and returns its elements as a new slice.
+func Collect[T any](seq iter.Seq[T]) []T {
+	result := make([]T, 0)
+	for e := range seq {
+		result = append(result, e)
+	}
+	return result
+}
+
+// CollectSet consumes the given iterator and returns its elements as a new Set.
+func CollectSet[T comparable](seq iter.Seq[T]) Set[T] {
+	result := NewSet[T]()
+	for e := range seq {
+		result.Add(e)
+	}
+	return result
+}
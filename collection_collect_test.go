@@ -0,0 +1,72 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("Collect", func() {
+	It("returns empty slice for empty iterator", func() {
+		Expect(collection.Collect(slices.Values([]int{}))).To(Equal([]int{}))
+	})
+	It("returns all elements in order", func() {
+		Expect(collection.Collect(slices.Values([]int{3, 1, 2}))).To(Equal([]int{3, 1, 2}))
+	})
+})
+
+var _ = Describe("CollectSet", func() {
+	It("returns set with unique elements", func() {
+		result := collection.CollectSet(slices.Values([]string{"a", "b", "a"}))
+		Expect(result.Slice()).To(ConsistOf("a", "b"))
+	})
+	It("chains lazy iterators without intermediate slices", func() {
+		set := collection.NewSet(1, 2, 3, 4)
+		result := collection.CollectSet(collection.FilterSeq(set.All(), func(value int) bool {
+			return value > 2
+		}))
+		Expect(result.Slice()).To(ConsistOf(3, 4))
+	})
+})
+
+var _ = Describe("Set All", func() {
+	It("iterates all elements of Set", func() {
+		set := collection.NewSet(1, 2, 3)
+		Expect(slices.Collect(set.All())).To(ConsistOf(1, 2, 3))
+	})
+	It("iterates all elements of SetHashCode", func() {
+		alice := User{Firstname: "Alice"}
+		bob := User{Firstname: "Bob"}
+		set := collection.NewSetHashCode(alice, bob)
+		Expect(slices.Collect(set.All())).To(ConsistOf(alice, bob))
+	})
+	It("iterates all elements of SetEqual in insertion order", func() {
+		alice := User{Firstname: "Alice"}
+		bob := User{Firstname: "Bob"}
+		set := collection.NewSetEqual(bob, alice)
+		Expect(slices.Collect(set.All())).To(Equal([]User{bob, alice}))
+	})
+	It("allows modifying the set during iteration", func() {
+		set := collection.NewSet(1, 2, 3)
+		for value := range set.All() {
+			set.Remove(value)
+		}
+		Expect(set.Length()).To(Equal(0))
+	})
+	It("stops when the consumer stops", func() {
+		set := collection.NewSet(1, 2, 3)
+		count := 0
+		for range set.All() {
+			count++
+			break
+		}
+		Expect(count).To(Equal(1))
+	})
+})
@@ -0,0 +1,264 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math"
+	"sync"
+
+	"github.com/bborbe/errors"
+)
+
+// CountMinSketch represents a thread-safe sketch estimating how often elements occur
+// in constant memory. Count never underestimates; with probability 1-delta it
+// overestimates by at most epsilon times Total.
+//
+// Elements are hashed from a stable byte representation, so sketches can be saved with
+// MarshalBinary and merged between runs.
+type CountMinSketch[T comparable] interface {
+	// Add increases the count of each element by one.
+	Add(elements ...T)
+	// AddN increases the count of element by n. Values of n <= 0 are ignored.
+	AddN(element T, n int)
+	// Count returns the estimated number of occurrences of element.
+	Count(element T) int
+	// Total returns the number of occurrences of all elements added.
+	Total() int
+	// Width returns the number of counters per row.
+	Width() uint64
+	// Depth returns the number of rows.
+	Depth() uint64
+	// Merge returns a new sketch with the counts of both sketches added.
+	// It returns an error if the sketches have different dimensions.
+	Merge(other CountMinSketch[T]) (CountMinSketch[T], error)
+	// Clone returns a copy of the sketch.
+	Clone() CountMinSketch[T]
+	// MarshalBinary implements encoding.BinaryMarshaler.
+	MarshalBinary() ([]byte, error)
+	// UnmarshalBinary implements encoding.BinaryUnmarshaler and replaces
+	// the dimensions and content of the sketch.
+	UnmarshalBinary(data []byte) error
+}
+
+// NewCountMinSketch creates a new thread-safe Count-Min sketch whose estimates exceed the
+// true count by at most epsilon times Total with probability 1-delta.
+// It returns an error if epsilon or delta is not between 0 and 1.
+//
+// Example:
+//
+//	sketch, err := collection.NewCountMinSketch[string](0.001, 0.01)
+//	sketch.Add("a", "b", "a")
+//	sketch.Count("a") // 2
+func NewCountMinSketch[T comparable](epsilon float64, delta float64) (CountMinSketch[T], error) {
+	ctx := context.Background()
+	if epsilon <= 0 || epsilon >= 1 {
+		return nil, errors.Errorf(ctx, "epsilon %v must be in (0, 1)", epsilon)
+	}
+	if delta <= 0 || delta >= 1 {
+		return nil, errors.Errorf(ctx, "delta %v must be in (0, 1)", delta)
+	}
+	return newCountMinSketch[T](
+		uint64(math.Ceil(math.E/epsilon)),
+		uint64(math.Ceil(math.Log(1/delta))),
+	), nil
+}
+
+func newCountMinSketch[T comparable](width uint64, depth uint64) *countMinSketch[T] {
+	return &countMinSketch[T]{
+		width:    width,
+		depth:    depth,
+		counters: make([]int, width*depth),
+		key:      stableKeyFunc[T](),
+	}
+}
+
+type countMinSketch[T comparable] struct {
+	mux      sync.Mutex
+	width    uint64
+	depth    uint64
+	counters []int
+	total    int
+	key      func(element T) []byte
+}
+
+// indexes returns the counter index of element in each row using double hashing.
+func (s *countMinSketch[T]) indexes(element T) []uint64 {
+	h1 := fnv64a(s.key(element))
+	h2 := splitmix64(h1) | 1
+	result := make([]uint64, s.depth)
+	for row := uint64(0); row < s.depth; row++ {
+		result[row] = row*s.width + (h1+row*h2)%s.width
+	}
+	return result
+}
+
+func (s *countMinSketch[T]) Add(elements ...T) {
+	for _, element := range elements {
+		s.AddN(element, 1)
+	}
+}
+
+func (s *countMinSketch[T]) AddN(element T, n int) {
+	if n <= 0 {
+		return
+	}
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, index := range s.indexes(element) {
+		s.counters[index] += n
+	}
+	s.total += n
+}
+
+func (s *countMinSketch[T]) Count(element T) int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	result := math.MaxInt
+	for _, index := range s.indexes(element) {
+		result = min(result, s.counters[index])
+	}
+	return result
+}
+
+func (s *countMinSketch[T]) Total() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.total
+}
+
+func (s *countMinSketch[T]) Width() uint64 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.width
+}
+
+func (s *countMinSketch[T]) Depth() uint64 {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.depth
+}
+
+// Merge returns a new sketch with the counts of both sketches added.
+// It returns an error if the sketches have different dimensions.
+func (s *countMinSketch[T]) Merge(other CountMinSketch[T]) (CountMinSketch[T], error) {
+	data, err := other.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrapf(context.Background(), err, "marshal other sketch failed")
+	}
+	var decoded countMinSketch[T]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	result := s.Clone().(*countMinSketch[T])
+	if result.width != decoded.width || result.depth != decoded.depth {
+		return nil, errors.Errorf(
+			context.Background(),
+			"merge of sketches with different dimensions %dx%d and %dx%d",
+			result.width,
+			result.depth,
+			decoded.width,
+			decoded.depth,
+		)
+	}
+	for i, counter := range decoded.counters {
+		result.counters[i] += counter
+	}
+	result.total += decoded.total
+	return result, nil
+}
+
+// Clone returns a copy of the sketch.
+func (s *countMinSketch[T]) Clone() CountMinSketch[T] {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	result := newCountMinSketch[T](s.width, s.depth)
+	copy(result.counters, s.counters)
+	result.total = s.total
+	return result
+}
+
+// The binary Count-Min sketch format is
+//
+//	version byte    countMinSketchBinaryVersion
+//	width   uvarint
+//	depth   uvarint
+//	total   varint
+//
+// followed by width*depth varint counters row by row.
+const countMinSketchBinaryVersion byte = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler for CountMinSketch.
+func (s *countMinSketch[T]) MarshalBinary() ([]byte, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	buf := []byte{countMinSketchBinaryVersion}
+	buf = binary.AppendUvarint(buf, s.width)
+	buf = binary.AppendUvarint(buf, s.depth)
+	buf = binary.AppendVarint(buf, int64(s.total))
+	for _, counter := range s.counters {
+		buf = binary.AppendVarint(buf, int64(counter))
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for CountMinSketch.
+// The sketch is left unchanged if data is invalid.
+func (s *countMinSketch[T]) UnmarshalBinary(data []byte) error {
+	ctx := context.Background()
+	if len(data) < 1 || data[0] != countMinSketchBinaryVersion {
+		return errors.New(ctx, "unsupported count-min sketch version")
+	}
+	reader := bytes.NewReader(data[1:])
+	width, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "read width failed")
+	}
+	depth, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "read depth failed")
+	}
+	total, err := binary.ReadVarint(reader)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "read total failed")
+	}
+	// every counter needs at least one byte, which bounds the allocation for corrupt input
+	remaining := uint64(reader.Len())
+	if width == 0 || depth == 0 || depth > remaining || width > remaining/depth || total < 0 {
+		return errors.Errorf(ctx, "invalid count-min sketch dimensions %dx%d", width, depth)
+	}
+	counters := make([]int, width*depth)
+	for i := range counters {
+		counter, err := binary.ReadVarint(reader)
+		if err != nil || counter < 0 {
+			return errors.Errorf(ctx, "invalid counter %d", i)
+		}
+		counters[i] = int(counter)
+	}
+	if reader.Len() > 0 {
+		return errors.Errorf(ctx, "%d trailing bytes in count-min sketch", reader.Len())
+	}
+
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	s.width = width
+	s.depth = depth
+	s.counters = counters
+	s.total = int(total)
+	if s.key == nil {
+		s.key = stableKeyFunc[T]()
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("CountMinSketch", func() {
+	var sketch collection.CountMinSketch[string]
+	BeforeEach(func() {
+		sketch = must(collection.NewCountMinSketch[string](0.001, 0.01))
+	})
+	It("is sized from epsilon and delta", func() {
+		Expect(sketch.Width()).To(Equal(uint64(2719)))
+		Expect(sketch.Depth()).To(Equal(uint64(5)))
+	})
+	It("estimates counts without underestimating", func() {
+		sketch.Add("a", "b", "a")
+		sketch.AddN("c", 10)
+		sketch.AddN("c", -1)
+		for i := 0; i < 10000; i++ {
+			sketch.Add(strconv.Itoa(i))
+		}
+		Expect(sketch.Total()).To(Equal(10013))
+		Expect(sketch.Count("a")).To(BeNumerically(">=", 2))
+		Expect(sketch.Count("c")).To(BeNumerically(">=", 10))
+		Expect(sketch.Count("c")).To(BeNumerically("<=", 10+10))
+		Expect(sketch.Count("missing")).To(BeNumerically("<=", 10))
+	})
+	It("merges sketches", func() {
+		other := sketch.Clone()
+		sketch.AddN("a", 3)
+		other.AddN("a", 4)
+		merged := must(sketch.Merge(other))
+		Expect(merged.Count("a")).To(Equal(7))
+		Expect(merged.Total()).To(Equal(7))
+		Expect(sketch.Count("a")).To(Equal(3))
+	})
+	It("rejects merging sketches with different dimensions", func() {
+		_, err := sketch.Merge(must(collection.NewCountMinSketch[string](0.01, 0.01)))
+		Expect(err).To(HaveOccurred())
+	})
+	DescribeTable("rejects invalid parameters",
+		func(epsilon float64, delta float64) {
+			_, err := collection.NewCountMinSketch[string](epsilon, delta)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("zero epsilon", 0.0, 0.01),
+		Entry("zero delta", 0.01, 0.0),
+		Entry("delta of one", 0.01, 1.0),
+	)
+	It("round-trips binary", func() {
+		sketch.AddN("a", 5)
+		decoded := must(collection.NewCountMinSketch[string](0.5, 0.5))
+		Expect(decoded.UnmarshalBinary(must(sketch.MarshalBinary()))).To(Succeed())
+		Expect(decoded.Width()).To(Equal(sketch.Width()))
+		Expect(decoded.Count("a")).To(Equal(5))
+		Expect(decoded.Total()).To(Equal(5))
+	})
+	DescribeTable("rejects invalid binary data",
+		func(data []byte) {
+			Expect(sketch.UnmarshalBinary(data)).NotTo(Succeed())
+			Expect(sketch.Width()).To(Equal(uint64(2719)))
+		},
+		Entry("empty", []byte{}),
+		Entry("unknown version", []byte{2, 1, 1, 0, 0}),
+		Entry("dimensions exceed data", []byte{1, 100, 100, 0, 0}),
+		Entry("negative counter", []byte{1, 1, 1, 0, 1}),
+		Entry("trailing bytes", []byte{1, 1, 1, 0, 0, 0}),
+	)
+})
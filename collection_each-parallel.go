@@ -0,0 +1,71 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"context"
+
+	"github.com/bborbe/errors"
+	"github.com/bborbe/run"
+)
+
+// eachParallel calls fn for each element using up to workers goroutines.
+// Values of workers below 1 are treated as 1. The first error cancels the remaining
+// calls, unless collectErrors is set, which calls fn for all elements and returns all
+// errors joined. It returns ctx.Err() if ctx is canceled.
+func eachParallel[T any](
+	ctx context.Context,
+	elements []T,
+	workers int,
+	collectErrors bool,
+	fn func(ctx context.Context, value T) error,
+) error {
+	workers = min(max(workers, 1), len(elements))
+	if workers == 0 {
+		return ctx.Err()
+	}
+
+	ch := make(chan T, workers)
+	funcs := make([]run.Func, 0, workers+1)
+	funcs = append(funcs, func(ctx context.Context) error {
+		defer close(ch)
+		for _, element := range elements {
+			select {
+			case <-ctx.Done():
+				return nil
+			case ch <- element:
+			}
+		}
+		return nil
+	})
+	for i := 0; i < workers; i++ {
+		funcs = append(funcs, func(ctx context.Context) error {
+			var errs []error
+			for element := range ch {
+				if ctx.Err() != nil {
+					return nil
+				}
+				if err := fn(ctx, element); err != nil {
+					if !collectErrors {
+						return err
+					}
+					errs = append(errs, err)
+				}
+			}
+			return errors.Join(errs...)
+		})
+	}
+
+	var err error
+	if collectErrors {
+		err = run.All(ctx, funcs...)
+	} else {
+		err = run.CancelOnFirstErrorWait(ctx, funcs...)
+	}
+	if err != nil {
+		return errors.Wrap(ctx, err, "each parallel failed")
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("EachParallel", func() {
+	var ctx context.Context
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+	It("calls fn for all elements", func() {
+		set := collection.NewSet(1, 2, 3, 4, 5)
+		var sum atomic.Int64
+		err := set.EachParallel(ctx, 3, func(ctx context.Context, value int) error {
+			sum.Add(int64(value))
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sum.Load()).To(Equal(int64(15)))
+	})
+	It("runs up to workers calls at once", func() {
+		set := collection.NewSet(1, 2, 3, 4, 5, 6)
+		release := make(chan struct{})
+		var running, maxRunning atomic.Int64
+		var mux sync.Mutex
+		started := 0
+		done := make(chan error)
+		go func() {
+			done <- set.EachParallel(ctx, 2, func(ctx context.Context, value int) error {
+				current := running.Add(1)
+				defer running.Add(-1)
+				mux.Lock()
+				started++
+				maxRunning.Store(max(maxRunning.Load(), current))
+				mux.Unlock()
+				<-release
+				return nil
+			})
+		}()
+		Eventually(func() int64 { return running.Load() }).Should(Equal(int64(2)))
+		close(release)
+		Expect(<-done).To(Succeed())
+		Expect(maxRunning.Load()).To(Equal(int64(2)))
+		Expect(started).To(Equal(6))
+	})
+	It("allows changes of the set from fn", func() {
+		set := collection.NewSet(1, 2, 3)
+		err := set.EachParallel(ctx, 2, func(ctx context.Context, value int) error {
+			set.Remove(value)
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(set.Length()).To(Equal(0))
+	})
+	It("stops on the first error", func() {
+		failed := errors.New("failed")
+		set := collection.NewSet(1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+		var calls atomic.Int64
+		err := set.EachParallel(ctx, 1, func(ctx context.Context, value int) error {
+			calls.Add(1)
+			return failed
+		})
+		Expect(errors.Is(err, failed)).To(BeTrue())
+		Expect(calls.Load()).To(Equal(int64(1)))
+	})
+	It("collects all errors", func() {
+		first := errors.New("first")
+		second := errors.New("second")
+		set := collection.NewSet(1, 2, 3)
+		err := set.EachParallelCollectErrors(ctx, 2, func(ctx context.Context, value int) error {
+			switch value {
+			case 1:
+				return first
+			case 2:
+				return second
+			}
+			return nil
+		})
+		Expect(errors.Is(err, first)).To(BeTrue())
+		Expect(errors.Is(err, second)).To(BeTrue())
+	})
+	It("returns the error of a canceled context", func() {
+		canceled, cancel := context.WithCancel(ctx)
+		cancel()
+		set := collection.NewSet(1, 2)
+		err := set.EachParallel(canceled, 2, func(ctx context.Context, value int) error {
+			return nil
+		})
+		Expect(err).To(MatchError(context.Canceled))
+	})
+	It("works with empty sets", func() {
+		set := collection.NewSet[int]()
+		err := set.EachParallel(ctx, 4, func(ctx context.Context, value int) error {
+			return errors.New("unexpected")
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("works with SetHashCode and SetEqual", func() {
+		users := []User{{Firstname: "a"}, {Firstname: "b"}}
+		var count atomic.Int64
+		fn := func(ctx context.Context, value User) error {
+			count.Add(1)
+			return nil
+		}
+		Expect(collection.NewSetHashCode(users...).EachParallel(ctx, 2, fn)).To(Succeed())
+		Expect(collection.NewSetEqual(users...).EachParallelCollectErrors(ctx, 2, fn)).To(Succeed())
+		Expect(count.Load()).To(Equal(int64(4)))
+	})
+})
@@ -25,3 +25,23 @@ func Each[T any](
 	}
 	return nil
 }
+
+// eachPair calls fn for each key with the value at the same index. Iteration stops on first error.
+func eachPair[K any, V any](
+	ctx context.Context,
+	keys []K,
+	values []V,
+	fn func(ctx context.Context, key K, value V) error,
+) error {
+	for i, key := range keys {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := fn(ctx, key, values[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/bborbe/errors"
+)
+
+// ElementCodec converts elements to bytes and back, e.g. to store them in a file.
+type ElementCodec[T any] interface {
+	// Encode returns the byte representation of element.
+	Encode(element T) ([]byte, error)
+	// Decode returns the element represented by data.
+	Decode(data []byte) (T, error)
+}
+
+// NewJSONElementCodec returns an ElementCodec using the JSON encoding of elements,
+// the same encoding Set uses in MarshalJSON.
+func NewJSONElementCodec[T any]() ElementCodec[T] {
+	return jsonElementCodec[T]{}
+}
+
+type jsonElementCodec[T any] struct{}
+
+func (jsonElementCodec[T]) Encode(element T) ([]byte, error) {
+	data, err := json.Marshal(element)
+	if err != nil {
+		return nil, errors.Wrapf(context.Background(), err, "encode element failed")
+	}
+	return data, nil
+}
+
+func (jsonElementCodec[T]) Decode(data []byte) (T, error) {
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, errors.Wrapf(context.Background(), err, "decode element failed")
+	}
+	return result, nil
+}
@@ -0,0 +1,25 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = DescribeTable("EqualUnordered",
+	func(a []string, b []string, expectedResult bool) {
+		Expect(collection.EqualUnordered(a, b)).To(Equal(expectedResult))
+	},
+	Entry("empty", []string{}, []string{}, true),
+	Entry("nil and empty", nil, []string{}, true),
+	Entry("same order", []string{"a", "b", "c"}, []string{"a", "b", "c"}, true),
+	Entry("different order", []string{"a", "b", "c"}, []string{"c", "a", "b"}, true),
+	Entry("duplicates", []string{"a", "a", "b"}, []string{"a", "b", "a"}, true),
+	Entry("different counts", []string{"a", "a", "b"}, []string{"a", "b", "b"}, false),
+	Entry("different length", []string{"a"}, []string{"a", "a"}, false),
+)
@@ -4,6 +4,8 @@
 
 package collection
 
+import "iter"
+
 // Exclude returns a new slice with all elements from the input slice
 // except those specified in the excludes parameter.
 func Exclude[T comparable](list []T, excludes ...T) []T {
@@ -20,3 +22,22 @@ func Exclude[T comparable](list []T, excludes ...T) []T {
 	}
 	return result
 }
+
+// ExcludeSeq returns an iterator over the elements of seq
+// except those specified in the excludes parameter.
+func ExcludeSeq[T comparable](seq iter.Seq[T], excludes ...T) iter.Seq[T] {
+	e := make(map[T]bool)
+	for _, exclude := range excludes {
+		e[exclude] = true
+	}
+	return func(yield func(T) bool) {
+		for l := range seq {
+			if e[l] {
+				continue
+			}
+			if !yield(l) {
+				return
+			}
+		}
+	}
+}
@@ -5,6 +5,8 @@
 package collection_test
 
 import (
+	"slices"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -59,3 +61,10 @@ var _ = Describe("Exclude", func() {
 		})
 	})
 })
+
+var _ = Describe("ExcludeSeq", func() {
+	It("yields elements not excluded", func() {
+		result := collection.ExcludeSeq(slices.Values([]string{"a", "b", "c", "d"}), "b", "d")
+		Expect(slices.Collect(result)).To(Equal([]string{"a", "c"}))
+	})
+})
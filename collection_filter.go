@@ -4,6 +4,8 @@
 
 package collection
 
+import "iter"
+
 // Filter returns a new slice containing only the elements from the input slice
 // that satisfy the given predicate function.
 func Filter[T any](list []T, match func(value T) bool) []T {
@@ -15,3 +17,31 @@ func Filter[T any](list []T, match func(value T) bool) []T {
 	}
 	return result
 }
+
+// FilterSeq returns an iterator over the elements of seq that satisfy the given predicate function.
+// Elements are evaluated lazily while the returned iterator is consumed.
+func FilterSeq[T any](seq iter.Seq[T], match func(value T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := range seq {
+			if match(e) && !yield(e) {
+				return
+			}
+		}
+	}
+}
+
+// FilterSeq2 returns an iterator over the key-value pairs of seq
+// that satisfy the given predicate function.
+// Pairs are evaluated lazily while the returned iterator is consumed.
+func FilterSeq2[K any, V any](
+	seq iter.Seq2[K, V],
+	match func(key K, value V) bool,
+) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if match(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
@@ -5,6 +5,9 @@
 package collection_test
 
 import (
+	"maps"
+	"slices"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -74,3 +77,37 @@ var _ = Describe("Filter", func() {
 		})
 	})
 })
+
+var _ = Describe("FilterSeq", func() {
+	It("yields only matching elements", func() {
+		result := collection.FilterSeq(slices.Values([]int{1, 2, 3, 4, 5}), func(value int) bool {
+			return value%2 == 1
+		})
+		Expect(slices.Collect(result)).To(Equal([]int{1, 3, 5}))
+	})
+	It("stops when the consumer stops", func() {
+		var result []int
+		seq := collection.FilterSeq(slices.Values([]int{1, 2, 3, 4}), func(value int) bool {
+			return true
+		})
+		for value := range seq {
+			result = append(result, value)
+			if value == 2 {
+				break
+			}
+		}
+		Expect(result).To(Equal([]int{1, 2}))
+	})
+})
+
+var _ = Describe("FilterSeq2", func() {
+	It("yields only matching pairs", func() {
+		result := collection.FilterSeq2(
+			slices.All([]string{"a", "b", "c"}),
+			func(key int, value string) bool {
+				return key != 1
+			},
+		)
+		Expect(maps.Collect(result)).To(Equal(map[int]string{0: "a", 2: "c"}))
+	})
+})
@@ -0,0 +1,386 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"hash/maphash"
+	"math/bits"
+)
+
+const (
+	hamtBits = 5
+	hamtMask = 1<<hamtBits - 1
+)
+
+// hamtSeed is shared by all tries so versions of different sets can be compared structurally.
+var hamtSeed = maphash.MakeSeed()
+
+// hamt is a persistent hash array mapped trie. Every modification returns a new trie that
+// shares all untouched nodes with the old one. The zero value is an empty trie.
+//
+// Deleting keeps the trie in a canonical shape: the same keys always result in the same
+// node layout, which allows equal to skip shared subtrees and compare the rest structurally.
+type hamt[K comparable, V any] struct {
+	root *hamtNode[K, V]
+	size int
+}
+
+// hamtOwner marks nodes created during one batch of modifications. Nodes owned by the
+// current batch are updated in place instead of being copied again.
+// It must not be zero sized, otherwise distinct owners could share the same address.
+type hamtOwner struct {
+	_ byte
+}
+
+type hamtNode[K comparable, V any] struct {
+	owner   *hamtOwner
+	bitmap  uint32
+	entries []hamtEntry[K, V]
+}
+
+// hamtEntry is either a child node or a list of leaves sharing the same full hash.
+type hamtEntry[K comparable, V any] struct {
+	child  *hamtNode[K, V]
+	hash   uint64
+	leaves []hamtLeaf[K, V]
+}
+
+type hamtLeaf[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// hamtBit returns the bitmap bit of hash on the level given by shift.
+func hamtBit(hash uint64, shift uint) uint32 {
+	return uint32(1) << ((hash >> shift) & hamtMask)
+}
+
+func (n *hamtNode[K, V]) position(bit uint32) int {
+	return bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+// editable returns n itself if it belongs to owner or a copy owned by owner.
+func (n *hamtNode[K, V]) editable(owner *hamtOwner) *hamtNode[K, V] {
+	if owner != nil && n.owner == owner {
+		return n
+	}
+	entries := make([]hamtEntry[K, V], len(n.entries), len(n.entries)+1)
+	copy(entries, n.entries)
+	return &hamtNode[K, V]{
+		owner:   owner,
+		bitmap:  n.bitmap,
+		entries: entries,
+	}
+}
+
+func (t hamt[K, V]) get(hash uint64, key K) (V, bool) {
+	node := t.root
+	for shift := uint(0); node != nil; shift += hamtBits {
+		bit := hamtBit(hash, shift)
+		if node.bitmap&bit == 0 {
+			break
+		}
+		entry := &node.entries[node.position(bit)]
+		if entry.child != nil {
+			node = entry.child
+			continue
+		}
+		if entry.hash != hash {
+			break
+		}
+		for _, leaf := range entry.leaves {
+			if leaf.key == key {
+				return leaf.value, true
+			}
+		}
+		break
+	}
+	var zero V
+	return zero, false
+}
+
+// put stores value for key. Existing keys are only updated if replace is set, otherwise
+// the trie is returned unchanged. It reports whether the key was added.
+func (t hamt[K, V]) put(
+	owner *hamtOwner,
+	hash uint64,
+	key K,
+	value V,
+	replace bool,
+) (hamt[K, V], bool) {
+	root := t.root
+	if root == nil {
+		root = &hamtNode[K, V]{owner: owner}
+	}
+	root, added, changed := root.put(owner, 0, hash, key, value, replace)
+	if !changed {
+		return t, false
+	}
+	if added {
+		return hamt[K, V]{root: root, size: t.size + 1}, true
+	}
+	return hamt[K, V]{root: root, size: t.size}, false
+}
+
+func (n *hamtNode[K, V]) put(
+	owner *hamtOwner,
+	shift uint,
+	hash uint64,
+	key K,
+	value V,
+	replace bool,
+) (*hamtNode[K, V], bool, bool) {
+	bit := hamtBit(hash, shift)
+	pos := n.position(bit)
+	if n.bitmap&bit == 0 {
+		result := n.editable(owner)
+		result.bitmap |= bit
+		result.entries = append(result.entries, hamtEntry[K, V]{})
+		copy(result.entries[pos+1:], result.entries[pos:])
+		result.entries[pos] = hamtEntry[K, V]{
+			hash:   hash,
+			leaves: []hamtLeaf[K, V]{{key: key, value: value}},
+		}
+		return result, true, true
+	}
+	entry := n.entries[pos]
+	switch {
+	case entry.child != nil:
+		child, added, changed := entry.child.put(owner, shift+hamtBits, hash, key, value, replace)
+		if !changed {
+			return n, false, false
+		}
+		result := n.editable(owner)
+		result.entries[pos].child = child
+		return result, added, true
+	case entry.hash == hash:
+		leaves, added, changed := putLeaf(entry.leaves, key, value, replace)
+		if !changed {
+			return n, false, false
+		}
+		result := n.editable(owner)
+		result.entries[pos].leaves = leaves
+		return result, added, true
+	default:
+		result := n.editable(owner)
+		result.entries[pos] = hamtEntry[K, V]{
+			child: mergeHamtEntries(owner, shift+hamtBits, entry, hamtEntry[K, V]{
+				hash:   hash,
+				leaves: []hamtLeaf[K, V]{{key: key, value: value}},
+			}),
+		}
+		return result, true, true
+	}
+}
+
+func putLeaf[K comparable, V any](
+	leaves []hamtLeaf[K, V],
+	key K,
+	value V,
+	replace bool,
+) ([]hamtLeaf[K, V], bool, bool) {
+	for i, leaf := range leaves {
+		if leaf.key != key {
+			continue
+		}
+		if !replace {
+			return leaves, false, false
+		}
+		result := make([]hamtLeaf[K, V], len(leaves))
+		copy(result, leaves)
+		result[i].value = value
+		return result, false, true
+	}
+	result := make([]hamtLeaf[K, V], len(leaves), len(leaves)+1)
+	copy(result, leaves)
+	return append(result, hamtLeaf[K, V]{key: key, value: value}), true, true
+}
+
+// mergeHamtEntries creates the nodes required to hold two leaf entries with different hashes.
+func mergeHamtEntries[K comparable, V any](
+	owner *hamtOwner,
+	shift uint,
+	a hamtEntry[K, V],
+	b hamtEntry[K, V],
+) *hamtNode[K, V] {
+	bitA := hamtBit(a.hash, shift)
+	bitB := hamtBit(b.hash, shift)
+	if bitA == bitB {
+		return &hamtNode[K, V]{
+			owner:   owner,
+			bitmap:  bitA,
+			entries: []hamtEntry[K, V]{{child: mergeHamtEntries(owner, shift+hamtBits, a, b)}},
+		}
+	}
+	if bitA > bitB {
+		a, b = b, a
+	}
+	return &hamtNode[K, V]{
+		owner:   owner,
+		bitmap:  bitA | bitB,
+		entries: []hamtEntry[K, V]{a, b},
+	}
+}
+
+// remove deletes key. The trie is returned unchanged if key is not present.
+func (t hamt[K, V]) remove(owner *hamtOwner, hash uint64, key K) (hamt[K, V], bool) {
+	if t.root == nil {
+		return t, false
+	}
+	root, removed := t.root.remove(owner, 0, hash, key)
+	if !removed {
+		return t, false
+	}
+	return hamt[K, V]{root: root, size: t.size - 1}, true
+}
+
+// remove returns the node without key, or nil if the node became empty.
+func (n *hamtNode[K, V]) remove(
+	owner *hamtOwner,
+	shift uint,
+	hash uint64,
+	key K,
+) (*hamtNode[K, V], bool) {
+	bit := hamtBit(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	pos := n.position(bit)
+	entry := n.entries[pos]
+	var replacement hamtEntry[K, V]
+	switch {
+	case entry.child != nil:
+		child, removed := entry.child.remove(owner, shift+hamtBits, hash, key)
+		if !removed {
+			return n, false
+		}
+		switch {
+		case child == nil:
+		case len(child.entries) == 1 && child.entries[0].child == nil:
+			// pull a single remaining leaf up to keep the trie canonical
+			replacement = child.entries[0]
+		default:
+			replacement = hamtEntry[K, V]{child: child}
+		}
+	case entry.hash == hash:
+		leaves, removed := removeLeaf(entry.leaves, key)
+		if !removed {
+			return n, false
+		}
+		if len(leaves) > 0 {
+			replacement = hamtEntry[K, V]{hash: hash, leaves: leaves}
+		}
+	default:
+		return n, false
+	}
+
+	if replacement.child != nil || len(replacement.leaves) > 0 {
+		result := n.editable(owner)
+		result.entries[pos] = replacement
+		return result, true
+	}
+	if len(n.entries) == 1 {
+		return nil, true
+	}
+	result := n.editable(owner)
+	result.bitmap &^= bit
+	result.entries = append(result.entries[:pos], result.entries[pos+1:]...)
+	return result, true
+}
+
+func removeLeaf[K comparable, V any](leaves []hamtLeaf[K, V], key K) ([]hamtLeaf[K, V], bool) {
+	for i, leaf := range leaves {
+		if leaf.key != key {
+			continue
+		}
+		result := make([]hamtLeaf[K, V], 0, len(leaves)-1)
+		result = append(result, leaves[:i]...)
+		return append(result, leaves[i+1:]...), true
+	}
+	return leaves, false
+}
+
+// each calls fn for all leaves until fn returns false.
+func (t hamt[K, V]) each(fn func(key K, value V) bool) bool {
+	if t.root == nil {
+		return true
+	}
+	return t.root.each(fn)
+}
+
+func (n *hamtNode[K, V]) each(fn func(key K, value V) bool) bool {
+	for _, entry := range n.entries {
+		if entry.child != nil {
+			if !entry.child.each(fn) {
+				return false
+			}
+			continue
+		}
+		for _, leaf := range entry.leaves {
+			if !fn(leaf.key, leaf.value) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// equalKeys reports whether both tries contain the same keys.
+// Shared subtrees are skipped, so versions derived from each other compare in
+// time proportional to their differences.
+func (t hamt[K, V]) equalKeys(other hamt[K, V]) bool {
+	if t.size != other.size {
+		return false
+	}
+	if t.root == other.root {
+		return true
+	}
+	if t.root == nil || other.root == nil {
+		return false
+	}
+	return t.root.equalKeys(other.root)
+}
+
+func (n *hamtNode[K, V]) equalKeys(other *hamtNode[K, V]) bool {
+	if n == other {
+		return true
+	}
+	if n.bitmap != other.bitmap {
+		return false
+	}
+	for i, entry := range n.entries {
+		otherEntry := other.entries[i]
+		if entry.child != nil || otherEntry.child != nil {
+			if entry.child == nil || otherEntry.child == nil ||
+				!entry.child.equalKeys(otherEntry.child) {
+				return false
+			}
+			continue
+		}
+		if entry.hash != otherEntry.hash || !equalHamtLeafKeys(entry.leaves, otherEntry.leaves) {
+			return false
+		}
+	}
+	return true
+}
+
+func equalHamtLeafKeys[K comparable, V any](a []hamtLeaf[K, V], b []hamtLeaf[K, V]) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, leaf := range a {
+		found := false
+		for _, otherLeaf := range b {
+			if leaf.key == otherLeaf.key {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
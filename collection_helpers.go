@@ -5,8 +5,20 @@
 package collection
 
 import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding"
+	"encoding/json"
 	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/bborbe/errors"
 )
 
 // elementToString converts an element to its string representation.
@@ -42,3 +54,219 @@ func formatSetString(prefix string, elements []string) string {
 	b.WriteString("]")
 	return b.String()
 }
+
+// parseTextElement converts the text form of a single element into T.
+// It uses encoding.TextUnmarshaler if *T implements it, time.ParseDuration for
+// time.Duration and strconv for types based on string, bool, int, uint and float.
+func parseTextElement[T any](text string) (T, error) {
+	var result T
+	switch target := any(&result).(type) {
+	case encoding.TextUnmarshaler:
+		if err := target.UnmarshalText([]byte(text)); err != nil {
+			return result, errors.Wrapf(context.Background(), err, "parse %q failed", text)
+		}
+		return result, nil
+	case *time.Duration:
+		duration, err := time.ParseDuration(text)
+		if err != nil {
+			return result, errors.Wrapf(context.Background(), err, "parse %q failed", text)
+		}
+		*target = duration
+		return result, nil
+	}
+	if err := parseTextValue(reflect.ValueOf(&result).Elem(), text); err != nil {
+		return result, errors.Wrapf(context.Background(), err, "parse %q failed", text)
+	}
+	return result, nil
+}
+
+// parseTextValue sets value from text based on the kind of value.
+func parseTextValue(value reflect.Value, text string) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(text)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return err
+		}
+		value.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(text, 10, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, value.Type().Bits())
+		if err != nil {
+			return err
+		}
+		value.SetFloat(f)
+	default:
+		return errors.Errorf(
+			context.Background(),
+			"unsupported element type %s",
+			value.Type(),
+		)
+	}
+	return nil
+}
+
+// splitText splits comma-separated text into trimmed, non-empty parts.
+func splitText(text string) []string {
+	parts := strings.FieldsFunc(text, func(r rune) bool {
+		return r == ','
+	})
+	result := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if trimmed := strings.TrimSpace(part); trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
+
+// naturalCompare returns a compare function for types based on string, int, uint or float,
+// or nil if T has no natural order.
+func naturalCompare[T any]() func(a, b T) int {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.String:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String())
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int())
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint())
+		}
+	case reflect.Float32, reflect.Float64:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float())
+		}
+	default:
+		return nil
+	}
+}
+
+// marshalSortedJSON serializes elements as a JSON array in a deterministic order.
+// Elements are sorted with compare, by their natural order, or by their JSON encoding
+// if neither is available. elements is sorted in place.
+func marshalSortedJSON[T any](elements []T, compare func(a, b T) int) ([]byte, error) {
+	if compare == nil {
+		compare = naturalCompare[T]()
+	}
+	if compare != nil {
+		slices.SortStableFunc(elements, compare)
+		return json.Marshal(elements)
+	}
+	encoded := make([]json.RawMessage, 0, len(elements))
+	for _, element := range elements {
+		data, err := json.Marshal(element)
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, data)
+	}
+	slices.SortFunc(encoded, func(a, b json.RawMessage) int {
+		return bytes.Compare(a, b)
+	})
+	return json.Marshal(encoded)
+}
+
+// sortElements sorts elements in place in the order of marshalSortedJSON without compare:
+// by their natural order, or by their JSON encoding if there is none.
+func sortElements[T any](elements []T) {
+	if compare := naturalCompare[T](); compare != nil {
+		slices.SortStableFunc(elements, compare)
+		return
+	}
+	keys := make([][]byte, len(elements))
+	order := make([]int, len(elements))
+	for i, element := range elements {
+		key, err := json.Marshal(element)
+		if err != nil {
+			key = []byte(elementToString(element))
+		}
+		keys[i] = key
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return bytes.Compare(keys[a], keys[b])
+	})
+	sorted := make([]T, len(elements))
+	for i, index := range order {
+		sorted[i] = elements[index]
+	}
+	copy(elements, sorted)
+}
+
+// marshalSortedText converts elements to comma-separated text in a deterministic order.
+// Elements are sorted with compare, by their natural order, or by their string
+// representation if neither is available. elements is sorted in place.
+func marshalSortedText[T any](elements []T, compare func(a, b T) int) []byte {
+	if compare == nil {
+		compare = naturalCompare[T]()
+	}
+	result := make([]string, 0, len(elements))
+	if compare != nil {
+		slices.SortStableFunc(elements, compare)
+	}
+	for _, element := range elements {
+		result = append(result, elementToString(element))
+	}
+	if compare == nil {
+		sort.Strings(result)
+	}
+	return []byte(strings.Join(result, ","))
+}
+
+// stableKeyFunc returns a function creating a stable byte representation of elements
+// using encoding.BinaryMarshaler, the built-in binary encoding of primitive kinds or JSON,
+// so hashes of elements do not change between runs.
+func stableKeyFunc[T any]() func(element T) []byte {
+	encode := json.Marshal
+	if hasElementBinaryCodec[T]() {
+		encode = func(element any) ([]byte, error) {
+			return marshalElementBinary(element.(T))
+		}
+	}
+	return func(element T) []byte {
+		data, err := encode(element)
+		if err != nil {
+			return fmt.Appendf(nil, "%#v", element)
+		}
+		return data
+	}
+}
+
+// fnv64a returns the 64-bit FNV-1a hash of data.
+func fnv64a(data []byte) uint64 {
+	const (
+		offset = 14695981039346656037
+		prime  = 1099511628211
+	)
+	hash := uint64(offset)
+	for _, b := range data {
+		hash ^= uint64(b)
+		hash *= prime
+	}
+	return hash
+}
+
+// splitmix64 scrambles x into a well-distributed 64-bit value.
+func splitmix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"math/bits"
+	"sync"
+
+	"github.com/bborbe/errors"
+)
+
+const (
+	// HyperLogLogMinPrecision is the smallest supported precision of a HyperLogLog.
+	HyperLogLogMinPrecision = 4
+	// HyperLogLogMaxPrecision is the largest supported precision of a HyperLogLog.
+	HyperLogLogMaxPrecision = 18
+	// HyperLogLogDefaultPrecision uses 16 KiB of registers for a standard error of about 0.8%.
+	HyperLogLogDefaultPrecision = 14
+)
+
+// HyperLogLog represents a thread-safe sketch estimating the number of distinct elements
+// in constant memory. With precision p it uses 2^p one-byte registers and has a standard
+// error of about 1.04/sqrt(2^p).
+//
+// Elements are hashed from a stable byte representation, so sketches can be saved with
+// MarshalBinary and merged between runs.
+type HyperLogLog[T comparable] interface {
+	// Add inserts elements into the sketch.
+	Add(elements ...T)
+	// Count returns the estimated number of distinct elements added.
+	Count() int
+	// Precision returns the number of index bits of the sketch.
+	Precision() uint8
+	// Merge returns a new sketch estimating the distinct elements of both sketches.
+	// It returns an error if the sketches have different precisions.
+	Merge(other HyperLogLog[T]) (HyperLogLog[T], error)
+	// Clone returns a copy of the sketch.
+	Clone() HyperLogLog[T]
+	// MarshalBinary implements encoding.BinaryMarshaler.
+	MarshalBinary() ([]byte, error)
+	// UnmarshalBinary implements encoding.BinaryUnmarshaler and replaces
+	// the precision and content of the sketch.
+	UnmarshalBinary(data []byte) error
+}
+
+// NewHyperLogLog creates a new thread-safe HyperLogLog sketch with the given precision.
+// It returns an error if precision is outside HyperLogLogMinPrecision and HyperLogLogMaxPrecision.
+//
+// Example:
+//
+//	visitors, err := collection.NewHyperLogLog[string](collection.HyperLogLogDefaultPrecision)
+//	visitors.Add(userID)
+//	visitors.Count() // approximate number of distinct users
+func NewHyperLogLog[T comparable](precision uint8) (HyperLogLog[T], error) {
+	if precision < HyperLogLogMinPrecision || precision > HyperLogLogMaxPrecision {
+		return nil, errors.Errorf(context.Background(), "unsupported precision %d", precision)
+	}
+	return newHyperLogLog[T](precision), nil
+}
+
+func newHyperLogLog[T comparable](precision uint8) *hyperLogLog[T] {
+	return &hyperLogLog[T]{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+		key:       stableKeyFunc[T](),
+	}
+}
+
+type hyperLogLog[T comparable] struct {
+	mux       sync.Mutex
+	precision uint8
+	registers []uint8
+	key       func(element T) []byte
+}
+
+func (h *hyperLogLog[T]) Add(elements ...T) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	for _, element := range elements {
+		hash := splitmix64(fnv64a(h.key(element)))
+		index := hash >> (64 - h.precision)
+		// the guard bit bounds the rank if all remaining bits are zero
+		zeros := bits.LeadingZeros64(hash<<h.precision | 1<<(h.precision-1))
+		rank := uint8(zeros + 1) // #nosec G115 -- zeros is at most 64
+		h.registers[index] = max(h.registers[index], rank)
+	}
+}
+
+// Count returns the estimated number of distinct elements added.
+// Small cardinalities are corrected with linear counting.
+func (h *hyperLogLog[T]) Count() int {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	m := float64(len(h.registers))
+	var sum float64
+	var zeros int
+	for _, register := range h.registers {
+		sum += math.Ldexp(1, -int(register))
+		if register == 0 {
+			zeros++
+		}
+	}
+	estimate := hyperLogLogAlpha(m) * m * m / sum
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return int(math.Round(estimate))
+}
+
+func (h *hyperLogLog[T]) Precision() uint8 {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	return h.precision
+}
+
+// Merge returns a new sketch estimating the distinct elements of both sketches.
+// It returns an error if the sketches have different precisions.
+func (h *hyperLogLog[T]) Merge(other HyperLogLog[T]) (HyperLogLog[T], error) {
+	data, err := other.MarshalBinary()
+	if err != nil {
+		return nil, errors.Wrapf(context.Background(), err, "marshal other sketch failed")
+	}
+	var decoded hyperLogLog[T]
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		return nil, err
+	}
+	result := h.Clone().(*hyperLogLog[T])
+	if result.precision != decoded.precision {
+		return nil, errors.Errorf(
+			context.Background(),
+			"merge of sketches with different precisions %d and %d",
+			result.precision,
+			decoded.precision,
+		)
+	}
+	for i, register := range decoded.registers {
+		result.registers[i] = max(result.registers[i], register)
+	}
+	return result, nil
+}
+
+// Clone returns a copy of the sketch.
+func (h *hyperLogLog[T]) Clone() HyperLogLog[T] {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	result := newHyperLogLog[T](h.precision)
+	copy(result.registers, h.registers)
+	return result
+}
+
+// The binary HyperLogLog format is
+//
+//	version   byte hyperLogLogBinaryVersion
+//	precision byte
+//
+// followed by 2^precision one-byte registers.
+const hyperLogLogBinaryVersion byte = 1
+
+// MarshalBinary implements encoding.BinaryMarshaler for HyperLogLog.
+func (h *hyperLogLog[T]) MarshalBinary() ([]byte, error) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	return append([]byte{hyperLogLogBinaryVersion, h.precision}, h.registers...), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for HyperLogLog.
+// The sketch is left unchanged if data is invalid.
+func (h *hyperLogLog[T]) UnmarshalBinary(data []byte) error {
+	ctx := context.Background()
+	if len(data) < 2 {
+		return errors.New(ctx, "binary hyperloglog data too short")
+	}
+	if data[0] != hyperLogLogBinaryVersion {
+		return errors.Errorf(ctx, "unsupported version %d", data[0])
+	}
+	precision := data[1]
+	if precision < HyperLogLogMinPrecision || precision > HyperLogLogMaxPrecision {
+		return errors.Errorf(ctx, "unsupported precision %d", precision)
+	}
+	if len(data)-2 != 1<<precision {
+		return errors.Errorf(ctx, "invalid hyperloglog length %d", len(data)-2)
+	}
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	h.precision = precision
+	h.registers = bytes.Clone(data[2:])
+	if h.key == nil {
+		h.key = stableKeyFunc[T]()
+	}
+	return nil
+}
+
+// hyperLogLogAlpha returns the bias correction constant for m registers.
+func hyperLogLogAlpha(m float64) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/m)
+	}
+}
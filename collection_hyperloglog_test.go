@@ -0,0 +1,75 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("HyperLogLog", func() {
+	var sketch collection.HyperLogLog[string]
+	BeforeEach(func() {
+		sketch = must(collection.NewHyperLogLog[string](collection.HyperLogLogDefaultPrecision))
+	})
+	addRange := func(sketch collection.HyperLogLog[string], from int, to int) {
+		for i := from; i < to; i++ {
+			sketch.Add(strconv.Itoa(i))
+		}
+	}
+	It("counts an empty sketch as zero", func() {
+		Expect(sketch.Count()).To(Equal(0))
+	})
+	It("counts small cardinalities exactly enough", func() {
+		sketch.Add("a", "b", "c", "a", "b")
+		Expect(sketch.Count()).To(Equal(3))
+	})
+	It("estimates large cardinalities", func() {
+		addRange(sketch, 0, 100000)
+		addRange(sketch, 0, 100000)
+		Expect(sketch.Count()).To(BeNumerically("~", 100000, 3000))
+	})
+	It("merges sketches", func() {
+		other := must(collection.NewHyperLogLog[string](collection.HyperLogLogDefaultPrecision))
+		addRange(sketch, 0, 20000)
+		addRange(other, 10000, 30000)
+		merged := must(sketch.Merge(other))
+		Expect(merged.Count()).To(BeNumerically("~", 30000, 1000))
+		Expect(sketch.Count()).To(BeNumerically("~", 20000, 700))
+	})
+	It("rejects merging sketches with different precisions", func() {
+		_, err := sketch.Merge(must(collection.NewHyperLogLog[string](10)))
+		Expect(err).To(HaveOccurred())
+	})
+	DescribeTable("rejects unsupported precisions",
+		func(precision uint8) {
+			_, err := collection.NewHyperLogLog[string](precision)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("too small", uint8(3)),
+		Entry("too large", uint8(19)),
+	)
+	It("round-trips binary", func() {
+		addRange(sketch, 0, 1000)
+		decoded := must(collection.NewHyperLogLog[string](4))
+		Expect(decoded.UnmarshalBinary(must(sketch.MarshalBinary()))).To(Succeed())
+		Expect(decoded.Precision()).To(Equal(uint8(collection.HyperLogLogDefaultPrecision)))
+		Expect(decoded.Count()).To(Equal(sketch.Count()))
+	})
+	DescribeTable("rejects invalid binary data",
+		func(data []byte) {
+			Expect(sketch.UnmarshalBinary(data)).NotTo(Succeed())
+			Expect(sketch.Precision()).To(Equal(uint8(collection.HyperLogLogDefaultPrecision)))
+		},
+		Entry("empty", []byte{}),
+		Entry("unknown version", []byte{2, 4}),
+		Entry("unsupported precision", []byte{1, 2, 0, 0, 0, 0}),
+		Entry("truncated", []byte{1, 4, 0, 0}),
+	)
+})
@@ -4,6 +4,8 @@
 
 package collection
 
+import "iter"
+
 // Join allow to join two arrays into one new array
 func Join[T any](a []T, b []T) []T {
 	result := make([]T, 0, len(a)+len(b))
@@ -11,3 +13,19 @@ func Join[T any](a []T, b []T) []T {
 	result = append(result, b...)
 	return result
 }
+
+// JoinSeq returns an iterator over all elements of a followed by all elements of b.
+func JoinSeq[T any](a iter.Seq[T], b iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for e := range a {
+			if !yield(e) {
+				return
+			}
+		}
+		for e := range b {
+			if !yield(e) {
+				return
+			}
+		}
+	}
+}
@@ -5,6 +5,8 @@
 package collection_test
 
 import (
+	"slices"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -18,3 +20,10 @@ var _ = DescribeTable("Join",
 	Entry("empty", []string{}, []string{}, []string{}),
 	Entry("with elements", []string{"a", "b"}, []string{"c", "d"}, []string{"a", "b", "c", "d"}),
 )
+
+var _ = Describe("JoinSeq", func() {
+	It("yields elements of both iterators", func() {
+		result := collection.JoinSeq(slices.Values([]int{1, 2}), slices.Values([]int{3}))
+		Expect(slices.Collect(result)).To(Equal([]int{1, 2, 3}))
+	})
+})
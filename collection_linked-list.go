@@ -0,0 +1,101 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+// linkedList is a minimal doubly linked list used to keep elements in a defined order
+// while allowing O(1) removal of known nodes. It is not thread-safe.
+type linkedList[T any] struct {
+	head   *linkedListNode[T]
+	tail   *linkedListNode[T]
+	length int
+}
+
+type linkedListNode[T any] struct {
+	value T
+	prev  *linkedListNode[T]
+	next  *linkedListNode[T]
+}
+
+// pushBack appends value to the end of the list and returns its node.
+func (l *linkedList[T]) pushBack(value T) *linkedListNode[T] {
+	node := &linkedListNode[T]{value: value}
+	l.linkBack(node)
+	return node
+}
+
+// pushFront inserts value at the start of the list and returns its node.
+func (l *linkedList[T]) pushFront(value T) *linkedListNode[T] {
+	node := &linkedListNode[T]{value: value}
+	l.linkFront(node)
+	return node
+}
+
+// remove unlinks node from the list.
+func (l *linkedList[T]) remove(node *linkedListNode[T]) {
+	if node.prev != nil {
+		node.prev.next = node.next
+	} else {
+		l.head = node.next
+	}
+	if node.next != nil {
+		node.next.prev = node.prev
+	} else {
+		l.tail = node.prev
+	}
+	node.prev = nil
+	node.next = nil
+	l.length--
+}
+
+// moveToFront moves node to the start of the list.
+func (l *linkedList[T]) moveToFront(node *linkedListNode[T]) {
+	if l.head == node {
+		return
+	}
+	l.remove(node)
+	l.linkFront(node)
+}
+
+// moveToBack moves node to the end of the list.
+func (l *linkedList[T]) moveToBack(node *linkedListNode[T]) {
+	if l.tail == node {
+		return
+	}
+	l.remove(node)
+	l.linkBack(node)
+}
+
+// values returns all values from head to tail.
+func (l *linkedList[T]) values() []T {
+	result := make([]T, 0, l.length)
+	for node := l.head; node != nil; node = node.next {
+		result = append(result, node.value)
+	}
+	return result
+}
+
+func (l *linkedList[T]) linkBack(node *linkedListNode[T]) {
+	node.prev = l.tail
+	node.next = nil
+	if l.tail != nil {
+		l.tail.next = node
+	} else {
+		l.head = node
+	}
+	l.tail = node
+	l.length++
+}
+
+func (l *linkedList[T]) linkFront(node *linkedListNode[T]) {
+	node.prev = nil
+	node.next = l.head
+	if l.head != nil {
+		l.head.prev = node
+	} else {
+		l.tail = node
+	}
+	l.head = node
+	l.length++
+}
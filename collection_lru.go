@@ -0,0 +1,260 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// LRU represents a thread-safe cache with a fixed capacity that evicts the least
+// recently used entry once the capacity is exceeded.
+//
+// Performance: This implementation combines a map with a doubly linked list and provides
+// O(1) average-case operations for Get, Put, Peek and Remove.
+type LRU[K comparable, V any] interface {
+	// Get returns the value of key and marks the entry as most recently used.
+	// It counts as a hit if key is present and as a miss otherwise.
+	Get(key K) (V, bool)
+	// Put inserts or updates the value of key and marks the entry as most recently used.
+	// If the cache is full, the least recently used entry is evicted.
+	Put(key K, value V)
+	// Peek returns the value of key without changing the recency or the counters.
+	Peek(key K) (V, bool)
+	// Remove deletes key and reports whether it was present.
+	// Removed entries are not passed to the eviction callback.
+	Remove(key K) bool
+	// Len returns the number of entries.
+	Len() int
+	// Capacity returns the maximum number of entries.
+	Capacity() int
+	// Stats returns the hit, miss and eviction counters.
+	Stats() LRUStats
+	// Each calls fn for each entry from the least to the most recently used.
+	// Iteration stops on first error and does not change the recency.
+	// fn may access and change the cache; changes are not reflected in the iteration.
+	Each(ctx context.Context, fn func(ctx context.Context, key K, value V) error) error
+	// Clone returns a new LRU with the same entries, recency, capacity and eviction callback.
+	// The counters of the clone start at zero.
+	Clone() LRU[K, V]
+	// MarshalJSON writes the entries from the least to the most recently used as
+	// a JSON array of {"key":...,"value":...} objects.
+	MarshalJSON() ([]byte, error)
+	// UnmarshalJSON replaces the entries of the cache. If the array contains more entries
+	// than the capacity, only the last ones are kept.
+	UnmarshalJSON(data []byte) error
+}
+
+// LRUStats contains the counters of an LRU.
+type LRUStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// NewLRU creates a new thread-safe LRU cache holding at most capacity entries.
+// A capacity below 1 is treated as 1.
+//
+// Example:
+//
+//	cache := collection.NewLRU[string, int](2)
+//	cache.Put("a", 1)
+//	cache.Put("b", 2)
+//	cache.Get("a")
+//	cache.Put("c", 3) // evicts "b"
+func NewLRU[K comparable, V any](capacity int) LRU[K, V] {
+	return newLRU[K, V](capacity, nil)
+}
+
+// NewLRUWithEvict creates a new thread-safe LRU cache holding at most capacity entries
+// that calls onEvict for every entry evicted because the capacity was exceeded.
+// onEvict is called after the cache is unlocked, so it may access the cache.
+func NewLRUWithEvict[K comparable, V any](
+	capacity int,
+	onEvict func(key K, value V),
+) LRU[K, V] {
+	return newLRU(capacity, onEvict)
+}
+
+func newLRU[K comparable, V any](capacity int, onEvict func(key K, value V)) *lru[K, V] {
+	return &lru[K, V]{
+		capacity: max(capacity, 1),
+		index:    make(map[K]*linkedListNode[lruEntry[K, V]]),
+		onEvict:  onEvict,
+	}
+}
+
+type lruEntry[K comparable, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// lru keeps the least recently used entry at the head of the list.
+type lru[K comparable, V any] struct {
+	mux      sync.Mutex
+	capacity int
+	list     linkedList[lruEntry[K, V]]
+	index    map[K]*linkedListNode[lruEntry[K, V]]
+	onEvict  func(key K, value V)
+	stats    LRUStats
+}
+
+// put inserts or updates an entry and returns the entries evicted by it.
+func (c *lru[K, V]) put(key K, value V) []lruEntry[K, V] {
+	if node, found := c.index[key]; found {
+		node.value.Value = value
+		c.list.moveToBack(node)
+		return nil
+	}
+	c.index[key] = c.list.pushBack(lruEntry[K, V]{Key: key, Value: value})
+	var evicted []lruEntry[K, V]
+	for c.list.length > c.capacity {
+		node := c.list.head
+		c.list.remove(node)
+		delete(c.index, node.value.Key)
+		c.stats.Evictions++
+		evicted = append(evicted, node.value)
+	}
+	return evicted
+}
+
+// evict passes evicted entries to the eviction callback.
+func (c *lru[K, V]) evict(evicted []lruEntry[K, V]) {
+	if c.onEvict == nil {
+		return
+	}
+	for _, entry := range evicted {
+		c.onEvict(entry.Key, entry.Value)
+	}
+}
+
+func (c *lru[K, V]) Get(key K) (V, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	node, found := c.index[key]
+	if !found {
+		c.stats.Misses++
+		var empty V
+		return empty, false
+	}
+	c.stats.Hits++
+	c.list.moveToBack(node)
+	return node.value.Value, true
+}
+
+func (c *lru[K, V]) Put(key K, value V) {
+	c.mux.Lock()
+	evicted := c.put(key, value)
+	c.mux.Unlock()
+
+	c.evict(evicted)
+}
+
+func (c *lru[K, V]) Peek(key K) (V, bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	node, found := c.index[key]
+	if !found {
+		var empty V
+		return empty, false
+	}
+	return node.value.Value, true
+}
+
+func (c *lru[K, V]) Remove(key K) bool {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	node, found := c.index[key]
+	if !found {
+		return false
+	}
+	c.list.remove(node)
+	delete(c.index, key)
+	return true
+}
+
+func (c *lru[K, V]) Len() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.list.length
+}
+
+func (c *lru[K, V]) Capacity() int {
+	return c.capacity
+}
+
+func (c *lru[K, V]) Stats() LRUStats {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return c.stats
+}
+
+// Each calls fn for each entry from the least to the most recently used.
+// Iteration stops on first error and does not change the recency.
+// fn may access and change the cache; changes are not reflected in the iteration.
+func (c *lru[K, V]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, key K, value V) error,
+) error {
+	c.mux.Lock()
+	keys := make([]K, 0, len(c.index))
+	values := make([]V, 0, len(c.index))
+	for node := c.list.head; node != nil; node = node.next {
+		keys = append(keys, node.value.Key)
+		values = append(values, node.value.Value)
+	}
+	c.mux.Unlock()
+
+	return eachPair(ctx, keys, values, fn)
+}
+
+// Clone returns a new LRU with the same entries, recency, capacity and eviction callback.
+// The counters of the clone start at zero.
+func (c *lru[K, V]) Clone() LRU[K, V] {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	result := newLRU(c.capacity, c.onEvict)
+	for node := c.list.head; node != nil; node = node.next {
+		result.put(node.value.Key, node.value.Value)
+	}
+	return result
+}
+
+// MarshalJSON implements json.Marshaler for LRU.
+// Entries are written from the least to the most recently used, so unmarshalling
+// restores the recency.
+func (c *lru[K, V]) MarshalJSON() ([]byte, error) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return json.Marshal(c.list.values())
+}
+
+// UnmarshalJSON implements json.Unmarshaler for LRU.
+// It replaces all entries without calling the eviction callback for the previous ones.
+// If the array contains more entries than the capacity, only the last ones are kept.
+func (c *lru[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []lruEntry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.list = linkedList[lruEntry[K, V]]{}
+	c.index = make(map[K]*linkedListNode[lruEntry[K, V]], len(entries))
+	for _, entry := range entries[max(len(entries)-c.capacity, 0):] {
+		c.put(entry.Key, entry.Value)
+	}
+	return nil
+}
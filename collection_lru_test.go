@@ -0,0 +1,118 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("LRU", func() {
+	var ctx context.Context
+	var evicted []string
+	var cache collection.LRU[string, int]
+	BeforeEach(func() {
+		ctx = context.Background()
+		evicted = nil
+		cache = collection.NewLRUWithEvict(2, func(key string, value int) {
+			evicted = append(evicted, key)
+		})
+	})
+	keys := func(cache collection.LRU[string, int]) []string {
+		var result []string
+		Expect(cache.Each(ctx, func(ctx context.Context, key string, value int) error {
+			result = append(result, key)
+			return nil
+		})).To(Succeed())
+		return result
+	}
+	It("evicts the least recently used entry", func() {
+		cache.Put("a", 1)
+		cache.Put("b", 2)
+		Expect(mustFind(cache.Get("a"))).To(Equal(1))
+		cache.Put("c", 3)
+		Expect(evicted).To(Equal([]string{"b"}))
+		Expect(keys(cache)).To(Equal([]string{"a", "c"}))
+		Expect(cache.Len()).To(Equal(2))
+	})
+	It("updates existing entries without eviction", func() {
+		cache.Put("a", 1)
+		cache.Put("b", 2)
+		cache.Put("a", 10)
+		Expect(evicted).To(BeEmpty())
+		Expect(mustFind(cache.Peek("a"))).To(Equal(10))
+		Expect(keys(cache)).To(Equal([]string{"b", "a"}))
+	})
+	It("counts hits, misses and evictions", func() {
+		cache.Put("a", 1)
+		cache.Get("a")
+		cache.Get("missing")
+		cache.Peek("missing")
+		cache.Put("b", 2)
+		cache.Put("c", 3)
+		Expect(cache.Stats()).To(Equal(collection.LRUStats{Hits: 1, Misses: 1, Evictions: 1}))
+	})
+	It("peeks without changing the recency", func() {
+		cache.Put("a", 1)
+		cache.Put("b", 2)
+		Expect(mustFind(cache.Peek("a"))).To(Equal(1))
+		cache.Put("c", 3)
+		Expect(evicted).To(Equal([]string{"a"}))
+	})
+	It("removes entries without calling the eviction callback", func() {
+		cache.Put("a", 1)
+		Expect(cache.Remove("a")).To(BeTrue())
+		Expect(cache.Remove("a")).To(BeFalse())
+		_, found := cache.Get("a")
+		Expect(found).To(BeFalse())
+		Expect(evicted).To(BeEmpty())
+	})
+	It("allows the eviction callback to access the cache", func() {
+		cache = collection.NewLRUWithEvict(1, func(key string, value int) {
+			evicted = append(evicted, key)
+			cache.Len()
+		})
+		cache.Put("a", 1)
+		cache.Put("b", 2)
+		Expect(evicted).To(Equal([]string{"a"}))
+	})
+	It("clones entries and recency", func() {
+		cache.Put("a", 1)
+		cache.Put("b", 2)
+		clone := cache.Clone()
+		clone.Put("c", 3)
+		Expect(keys(clone)).To(Equal([]string{"b", "c"}))
+		Expect(keys(cache)).To(Equal([]string{"a", "b"}))
+		Expect(clone.Capacity()).To(Equal(2))
+	})
+	It("round-trips json", func() {
+		cache.Put("a", 1)
+		cache.Put("b", 2)
+		cache.Get("a")
+		data, err := json.Marshal(cache)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`[{"key":"b","value":2},{"key":"a","value":1}]`))
+
+		decoded := collection.NewLRU[string, int](2)
+		Expect(json.Unmarshal(data, decoded)).To(Succeed())
+		Expect(keys(decoded)).To(Equal([]string{"b", "a"}))
+	})
+	It("keeps the last entries if json exceeds the capacity", func() {
+		decoded := collection.NewLRU[string, int](1)
+		data := []byte(`[{"key":"a","value":1},{"key":"b","value":2}]`)
+		Expect(json.Unmarshal(data, decoded)).To(Succeed())
+		Expect(keys(decoded)).To(Equal([]string{"b"}))
+	})
+})
+
+func mustFind[T any](value T, found bool) T {
+	Expect(found).To(BeTrue())
+	return value
+}
@@ -4,7 +4,10 @@
 
 package collection
 
-import "context"
+import (
+	"context"
+	"iter"
+)
 
 // Map applies the given function to each element and returns a new slice with the transformed results.
 // It transforms []A to []B by applying fn to each element of type A, producing elements of type B.
@@ -29,3 +32,32 @@ func Map[A any, B any](
 	}
 	return result, nil
 }
+
+// MapSeq returns an iterator that applies fn lazily to each element of seq.
+// Each transformed value is yielded together with a nil error. If fn returns an error
+// or the context is canceled, the error is yielded with the zero value of B and iteration stops.
+func MapSeq[A any, B any](
+	ctx context.Context,
+	seq iter.Seq[A],
+	fn func(ctx context.Context, value A) (B, error),
+) iter.Seq2[B, error] {
+	return func(yield func(B, error) bool) {
+		var zero B
+		for element := range seq {
+			select {
+			case <-ctx.Done():
+				yield(zero, ctx.Err())
+				return
+			default:
+				transformed, err := fn(ctx, element)
+				if err != nil {
+					yield(zero, err)
+					return
+				}
+				if !yield(transformed, nil) {
+					return
+				}
+			}
+		}
+	}
+}
@@ -6,7 +6,9 @@ package collection_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -148,3 +150,60 @@ var _ = Describe("Map", func() {
 		}))
 	})
 })
+
+var _ = Describe("MapSeq", func() {
+	var ctx context.Context
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+	It("transforms elements lazily", func() {
+		var result []string
+		seq := collection.MapSeq(
+			ctx,
+			slices.Values([]int{1, 2, 3}),
+			func(ctx context.Context, value int) (string, error) {
+				return strconv.Itoa(value * 2), nil
+			},
+		)
+		for value, err := range seq {
+			Expect(err).To(BeNil())
+			result = append(result, value)
+		}
+		Expect(result).To(Equal([]string{"2", "4", "6"}))
+	})
+	It("yields the error and stops", func() {
+		var errs []error
+		var values []int
+		seq := collection.MapSeq(
+			ctx,
+			slices.Values([]int{1, 2, 3}),
+			func(ctx context.Context, value int) (int, error) {
+				if value == 2 {
+					return 0, errors.New("banana")
+				}
+				return value, nil
+			},
+		)
+		for value, err := range seq {
+			values = append(values, value)
+			errs = append(errs, err)
+		}
+		Expect(values).To(Equal([]int{1, 0}))
+		Expect(errs[0]).To(BeNil())
+		Expect(errs[1]).To(MatchError("banana"))
+	})
+	It("yields context error when canceled", func() {
+		ctx, cancel := context.WithCancel(ctx)
+		cancel()
+		seq := collection.MapSeq(
+			ctx,
+			slices.Values([]int{1}),
+			func(ctx context.Context, value int) (int, error) {
+				return value, nil
+			},
+		)
+		for _, err := range seq {
+			Expect(err).To(MatchError(context.Canceled))
+		}
+	})
+})
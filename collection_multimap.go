@@ -0,0 +1,276 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"sort"
+	"sync"
+)
+
+// MultiMap represents a thread-safe mapping from keys to sets of values.
+// Keys without values do not exist: Put creates a key on its first value and
+// Remove drops it once its last value is removed.
+type MultiMap[K comparable, V comparable] interface {
+	// Put adds values to the set of key. Duplicate values are ignored.
+	Put(key K, values ...V)
+	// Remove deletes values from the set of key and drops key if no values remain.
+	Remove(key K, values ...V)
+	// RemoveAll deletes key with all its values.
+	RemoveAll(keys ...K)
+	// Get returns a new Set containing the values of key, which is empty if key is missing.
+	// Changes to the returned set don't affect the MultiMap.
+	Get(key K) Set[V]
+	// Contains reports whether value is in the set of key.
+	Contains(key K, value V) bool
+	// ContainsKey reports whether key has at least one value.
+	ContainsKey(key K) bool
+	// Keys returns a new Set containing all keys.
+	Keys() Set[K]
+	// Length returns the number of keys.
+	Length() int
+	// Size returns the number of key-value pairs.
+	Size() int
+	// Each calls fn for each key-value pair. Iteration stops on first error.
+	// The order of iteration is arbitrary and not guaranteed to be consistent.
+	// fn may access and change the map; changes are not reflected in the iteration.
+	Each(ctx context.Context, fn func(ctx context.Context, key K, value V) error) error
+	// Inverse returns a new MultiMap mapping each value to the set of its keys.
+	Inverse() MultiMap[V, K]
+	// Clone returns a new MultiMap containing all key-value pairs of the current map.
+	Clone() MultiMap[K, V]
+	// String returns a human-readable representation in the form "MultiMap[a:[1, 2], b:[3]]".
+	String() string
+	// UnmarshalJSON replaces the content with a JSON object mapping keys to arrays of values.
+	UnmarshalJSON(data []byte) error
+	// MarshalJSON writes the map as a JSON object mapping keys to arrays of values.
+	MarshalJSON() ([]byte, error)
+}
+
+// NewMultiMap creates a new empty thread-safe mapping from keys to sets of values.
+//
+// Example:
+//
+//	members := collection.NewMultiMap[string, string]()
+//	members.Put("admins", "alice", "bob")
+//	members.Get("admins").Contains("alice") // true
+func NewMultiMap[K comparable, V comparable]() MultiMap[K, V] {
+	return newMultiMap[K, V]()
+}
+
+func newMultiMap[K comparable, V comparable]() *multiMap[K, V] {
+	return &multiMap[K, V]{
+		data: make(map[K]map[V]struct{}),
+	}
+}
+
+type multiMap[K comparable, V comparable] struct {
+	mux  sync.Mutex
+	data map[K]map[V]struct{}
+}
+
+func (m *multiMap[K, V]) Put(key K, values ...V) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.putLocked(key, values...)
+}
+
+func (m *multiMap[K, V]) putLocked(key K, values ...V) {
+	if len(values) == 0 {
+		return
+	}
+	set, found := m.data[key]
+	if !found {
+		set = make(map[V]struct{}, len(values))
+		m.data[key] = set
+	}
+	for _, value := range values {
+		set[value] = struct{}{}
+	}
+}
+
+func (m *multiMap[K, V]) Remove(key K, values ...V) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	set, found := m.data[key]
+	if !found {
+		return
+	}
+	for _, value := range values {
+		delete(set, value)
+	}
+	if len(set) == 0 {
+		delete(m.data, key)
+	}
+}
+
+func (m *multiMap[K, V]) RemoveAll(keys ...K) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for _, key := range keys {
+		delete(m.data, key)
+	}
+}
+
+func (m *multiMap[K, V]) Get(key K) Set[V] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return NewSet(slices.Collect(maps.Keys(m.data[key]))...)
+}
+
+func (m *multiMap[K, V]) Contains(key K, value V) bool {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	_, found := m.data[key][value]
+	return found
+}
+
+func (m *multiMap[K, V]) ContainsKey(key K) bool {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	_, found := m.data[key]
+	return found
+}
+
+func (m *multiMap[K, V]) Keys() Set[K] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return NewSet(slices.Collect(maps.Keys(m.data))...)
+}
+
+func (m *multiMap[K, V]) Length() int {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return len(m.data)
+}
+
+func (m *multiMap[K, V]) Size() int {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	var result int
+	for _, set := range m.data {
+		result += len(set)
+	}
+	return result
+}
+
+// Each calls fn for each key-value pair. Iteration stops on first error.
+// The order of iteration is arbitrary and not guaranteed to be consistent.
+// fn may access and change the map; changes are not reflected in the iteration.
+func (m *multiMap[K, V]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, key K, value V) error,
+) error {
+	m.mux.Lock()
+	var keys []K
+	var values []V
+	for key, set := range m.data {
+		for value := range set {
+			keys = append(keys, key)
+			values = append(values, value)
+		}
+	}
+	m.mux.Unlock()
+
+	return eachPair(ctx, keys, values, fn)
+}
+
+// Inverse returns a new MultiMap mapping each value to the set of its keys.
+func (m *multiMap[K, V]) Inverse() MultiMap[V, K] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	result := newMultiMap[V, K]()
+	for key, set := range m.data {
+		for value := range set {
+			result.putLocked(value, key)
+		}
+	}
+	return result
+}
+
+// Clone returns a new MultiMap containing all key-value pairs of the current map.
+// The returned map is a shallow copy - modifications to it won't affect the original.
+func (m *multiMap[K, V]) Clone() MultiMap[K, V] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	result := newMultiMap[K, V]()
+	for key, set := range m.data {
+		result.data[key] = maps.Clone(set)
+	}
+	return result
+}
+
+// String returns a human-readable string representation of the map.
+// Format: "MultiMap[key1:[value1, value2], key2:[value3]]" with keys and values sorted
+// by their string representation.
+func (m *multiMap[K, V]) String() string {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	result := make([]string, 0, len(m.data))
+	for key, set := range m.data {
+		values := make([]string, 0, len(set))
+		for value := range set {
+			values = append(values, elementToString(value))
+		}
+		sort.Strings(values)
+		result = append(result, elementToString(key)+":"+formatSetString("[", values))
+	}
+	sort.Strings(result)
+	return formatSetString("MultiMap[", result)
+}
+
+// MarshalJSON implements json.Marshaler for MultiMap.
+// It serializes the map as a JSON object of arrays, so K must be a string or integer type
+// or implement encoding.TextMarshaler. Values are written in the same deterministic order
+// as Set.MarshalJSON.
+func (m *multiMap[K, V]) MarshalJSON() ([]byte, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	result := make(map[K]json.RawMessage, len(m.data))
+	for key, set := range m.data {
+		data, err := marshalSortedJSON(slices.Collect(maps.Keys(set)), nil)
+		if err != nil {
+			return nil, err
+		}
+		result[key] = data
+	}
+	return json.Marshal(result)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for MultiMap.
+// It replaces the content of the map with the entries of a JSON object of arrays.
+// Keys with empty arrays are skipped.
+func (m *multiMap[K, V]) UnmarshalJSON(data []byte) error {
+	var entries map[K][]V
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	result := newMultiMap[K, V]()
+	for key, values := range entries {
+		result.putLocked(key, values...)
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.data = result.data
+	return nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("MultiMap", func() {
+	var m collection.MultiMap[string, int]
+	BeforeEach(func() {
+		m = collection.NewMultiMap[string, int]()
+		m.Put("a", 1, 2, 2)
+		m.Put("b", 3)
+	})
+	It("groups values by key", func() {
+		Expect(m.Get("a").Length()).To(Equal(2))
+		Expect(m.Contains("a", 2)).To(BeTrue())
+		Expect(m.Contains("b", 2)).To(BeFalse())
+		Expect(m.Get("c").Length()).To(Equal(0))
+		Expect(m.Length()).To(Equal(2))
+		Expect(m.Size()).To(Equal(3))
+		Expect(m.String()).To(Equal("MultiMap[a:[1, 2], b:[3]]"))
+	})
+	It("returns independent sets from Get", func() {
+		m.Get("a").Add(5)
+		Expect(m.Contains("a", 5)).To(BeFalse())
+	})
+	It("drops keys without values", func() {
+		m.Remove("b", 3)
+		Expect(m.ContainsKey("b")).To(BeFalse())
+		m.Put("c")
+		Expect(m.ContainsKey("c")).To(BeFalse())
+		m.RemoveAll("a")
+		Expect(m.Length()).To(Equal(0))
+	})
+	It("inverts the mapping", func() {
+		m.Put("b", 1)
+		inverse := m.Inverse()
+		Expect(inverse.String()).To(Equal("MultiMap[1:[a, b], 2:[a], 3:[b]]"))
+	})
+	It("clones without affecting the original", func() {
+		clone := m.Clone()
+		clone.Put("a", 9)
+		Expect(m.Contains("a", 9)).To(BeFalse())
+		Expect(clone.Contains("a", 9)).To(BeTrue())
+	})
+	It("iterates all pairs", func() {
+		var values []int
+		err := m.Each(context.Background(), func(ctx context.Context, key string, value int) error {
+			values = append(values, value)
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		sort.Ints(values)
+		Expect(values).To(Equal([]int{1, 2, 3}))
+	})
+	It("round-trips json", func() {
+		data, err := json.Marshal(m)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"a":[1,2],"b":[3]}`))
+
+		decoded := collection.NewMultiMap[string, int]()
+		decoded.Put("x", 1)
+		Expect(json.Unmarshal([]byte(`{"a":[2,1,2],"b":[]}`), decoded)).To(Succeed())
+		Expect(decoded.String()).To(Equal("MultiMap[a:[1, 2]]"))
+	})
+})
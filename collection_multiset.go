@@ -0,0 +1,346 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"github.com/bborbe/errors"
+)
+
+// Multiset represents a thread-safe bag of comparable elements that counts how often
+// each element occurs. Elements with a count of zero are not part of the multiset.
+//
+// Performance: This implementation uses a map of counts and provides O(1) average-case
+// operations for Add, Remove and Count.
+type Multiset[T comparable] interface {
+	// Add increases the count of each element by one.
+	Add(elements ...T)
+	// AddN increases the count of element by n. Values of n <= 0 are ignored.
+	AddN(element T, n int)
+	// Remove decreases the count of each element by one.
+	Remove(elements ...T)
+	// RemoveN decreases the count of element by up to n and returns the number of
+	// occurrences actually removed.
+	RemoveN(element T, n int) int
+	// Count returns how often element occurs.
+	Count(element T) int
+	// Contains reports whether element occurs at least once.
+	Contains(element T) bool
+	// Length returns the number of distinct elements.
+	Length() int
+	// Total returns the number of occurrences of all elements.
+	Total() int
+	// Distinct returns a Set of all elements occurring at least once.
+	Distinct() Set[T]
+	// Entries returns all elements with their counts in arbitrary order.
+	Entries() []MultisetEntry[T]
+	// MostCommon returns the n elements with the highest counts in descending order.
+	// Elements with equal counts are ordered naturally if T has a natural order.
+	// All elements are returned if n <= 0 or n exceeds the number of distinct elements.
+	MostCommon(n int) []MultisetEntry[T]
+	// Each calls fn for each distinct element with its count. Iteration stops on first error.
+	// The order of iteration is arbitrary and not guaranteed to be consistent.
+	// fn may access and change the multiset; changes are not reflected in the iteration.
+	Each(ctx context.Context, fn func(ctx context.Context, element T, count int) error) error
+	// Clone returns a new Multiset with the same counts.
+	Clone() Multiset[T]
+	// Union returns a new Multiset with the maximum count of each element in both multisets.
+	Union(other Multiset[T]) Multiset[T]
+	// Intersection returns a new Multiset with the minimum count of each element in both multisets.
+	Intersection(other Multiset[T]) Multiset[T]
+	// Sum returns a new Multiset with the counts of both multisets added.
+	Sum(other Multiset[T]) Multiset[T]
+	// String returns a human-readable representation in the form "Multiset[a:2, b:1]".
+	String() string
+	// MarshalJSON writes the multiset as a JSON object mapping each element to its count.
+	MarshalJSON() ([]byte, error)
+	// UnmarshalJSON replaces the content with a JSON object mapping elements to counts.
+	UnmarshalJSON(data []byte) error
+}
+
+// MultisetEntry is an element of a Multiset with its count.
+type MultisetEntry[T any] struct {
+	Element T
+	Count   int
+}
+
+// NewMultiset creates a new thread-safe multiset.
+// It accepts optional initial elements, each occurrence increases the count by one.
+//
+// Example:
+//
+//	words := collection.NewMultiset("a", "b", "a")
+//	words.Count("a") // 2
+//	words.MostCommon(1) // [{a 2}]
+func NewMultiset[T comparable](elements ...T) Multiset[T] {
+	return newMultiset(elements...)
+}
+
+func newMultiset[T comparable](elements ...T) *multiset[T] {
+	m := &multiset[T]{
+		data: make(map[T]int),
+	}
+	m.Add(elements...)
+	return m
+}
+
+type multiset[T comparable] struct {
+	mux  sync.Mutex
+	data map[T]int
+}
+
+func (m *multiset[T]) Add(elements ...T) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for _, element := range elements {
+		m.data[element]++
+	}
+}
+
+func (m *multiset[T]) AddN(element T, n int) {
+	if n <= 0 {
+		return
+	}
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.data[element] += n
+}
+
+func (m *multiset[T]) Remove(elements ...T) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for _, element := range elements {
+		m.removeN(element, 1)
+	}
+}
+
+func (m *multiset[T]) RemoveN(element T, n int) int {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return m.removeN(element, n)
+}
+
+// removeN decreases the count of element by up to n and returns the removed occurrences.
+func (m *multiset[T]) removeN(element T, n int) int {
+	count := m.data[element]
+	removed := min(max(n, 0), count)
+	if removed == count {
+		delete(m.data, element)
+	} else {
+		m.data[element] = count - removed
+	}
+	return removed
+}
+
+func (m *multiset[T]) Count(element T) int {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return m.data[element]
+}
+
+func (m *multiset[T]) Contains(element T) bool {
+	return m.Count(element) > 0
+}
+
+func (m *multiset[T]) Length() int {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return len(m.data)
+}
+
+func (m *multiset[T]) Total() int {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	var result int
+	for _, count := range m.data {
+		result += count
+	}
+	return result
+}
+
+func (m *multiset[T]) Distinct() Set[T] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	result := make([]T, 0, len(m.data))
+	for element := range m.data {
+		result = append(result, element)
+	}
+	return newSet(result...)
+}
+
+func (m *multiset[T]) Entries() []MultisetEntry[T] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	result := make([]MultisetEntry[T], 0, len(m.data))
+	for element, count := range m.data {
+		result = append(result, MultisetEntry[T]{Element: element, Count: count})
+	}
+	return result
+}
+
+func (m *multiset[T]) MostCommon(n int) []MultisetEntry[T] {
+	result := m.Entries()
+	sortEntriesByCount(result)
+	if n > 0 && n < len(result) {
+		result = result[:n]
+	}
+	return result
+}
+
+// Each calls fn for each distinct element with its count. Iteration stops on first error.
+// The order of iteration is arbitrary and not guaranteed to be consistent.
+// fn may access and change the multiset; changes are not reflected in the iteration.
+func (m *multiset[T]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, element T, count int) error,
+) error {
+	m.mux.Lock()
+	elements := make([]T, 0, len(m.data))
+	counts := make([]int, 0, len(m.data))
+	for element, count := range m.data {
+		elements = append(elements, element)
+		counts = append(counts, count)
+	}
+	m.mux.Unlock()
+
+	return eachPair(ctx, elements, counts, fn)
+}
+
+// Clone returns a new Multiset with the same counts.
+// The returned multiset is a shallow copy - modifications to it won't affect the original.
+func (m *multiset[T]) Clone() Multiset[T] {
+	return m.merge(nil, func(a, b int) int { return a })
+}
+
+// Union returns a new Multiset with the maximum count of each element in both multisets.
+func (m *multiset[T]) Union(other Multiset[T]) Multiset[T] {
+	return m.merge(other.Entries(), func(a, b int) int { return max(a, b) })
+}
+
+// Intersection returns a new Multiset with the minimum count of each element in both multisets.
+func (m *multiset[T]) Intersection(other Multiset[T]) Multiset[T] {
+	entries := other.Entries()
+	result := m.merge(entries, func(a, b int) int { return min(a, b) })
+	present := make(map[T]struct{}, len(entries))
+	for _, entry := range entries {
+		present[entry.Element] = struct{}{}
+	}
+	for element := range result.data {
+		if _, found := present[element]; !found {
+			delete(result.data, element)
+		}
+	}
+	return result
+}
+
+// Sum returns a new Multiset with the counts of both multisets added.
+func (m *multiset[T]) Sum(other Multiset[T]) Multiset[T] {
+	return m.merge(other.Entries(), func(a, b int) int { return a + b })
+}
+
+// merge returns a copy of m with the counts of entries combined by fn.
+// Entries are taken as a snapshot before m is locked, so m and other may be the same multiset.
+func (m *multiset[T]) merge(entries []MultisetEntry[T], fn func(a, b int) int) *multiset[T] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	result := newMultiset[T]()
+	for element, count := range m.data {
+		result.data[element] = count
+	}
+	for _, entry := range entries {
+		if count := fn(result.data[entry.Element], entry.Count); count > 0 {
+			result.data[entry.Element] = count
+		} else {
+			delete(result.data, entry.Element)
+		}
+	}
+	return result
+}
+
+// String returns a human-readable string representation of the multiset.
+// Format: "Multiset[element1:count1, element2:count2, ...]" sorted by element.
+func (m *multiset[T]) String() string {
+	entries := m.Entries()
+	result := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, fmt.Sprintf("%s:%d", elementToString(entry.Element), entry.Count))
+	}
+	sort.Strings(result)
+	return formatSetString("Multiset[", result)
+}
+
+// MarshalJSON implements json.Marshaler for Multiset.
+// It serializes the multiset as a JSON object mapping each element to its count,
+// so T must be a string or integer type or implement encoding.TextMarshaler.
+func (m *multiset[T]) MarshalJSON() ([]byte, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return json.Marshal(m.data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for Multiset.
+// It replaces the content of the multiset. Elements with a count of zero are skipped
+// and negative counts are rejected.
+func (m *multiset[T]) UnmarshalJSON(data []byte) error {
+	var counts map[T]int
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return err
+	}
+	for element, count := range counts {
+		if count < 0 {
+			return errors.Errorf(
+				context.Background(),
+				"negative count %d for element %v",
+				count,
+				element,
+			)
+		}
+		if count == 0 {
+			delete(counts, element)
+		}
+	}
+	if counts == nil {
+		counts = make(map[T]int)
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.data = counts
+	return nil
+}
+
+// sortEntriesByCount sorts entries by descending count. Elements with equal counts
+// are ordered naturally if T has a natural order.
+func sortEntriesByCount[T any](entries []MultisetEntry[T]) {
+	compare := naturalCompare[T]()
+	slices.SortStableFunc(entries, func(a, b MultisetEntry[T]) int {
+		if a.Count != b.Count {
+			return cmp.Compare(b.Count, a.Count)
+		}
+		if compare == nil {
+			return 0
+		}
+		return compare(a.Element, b.Element)
+	})
+}
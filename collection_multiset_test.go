@@ -0,0 +1,98 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("Multiset", func() {
+	var bag collection.Multiset[string]
+	BeforeEach(func() {
+		bag = collection.NewMultiset("a", "b", "a", "c", "a", "b")
+	})
+	It("counts elements", func() {
+		Expect(bag.Count("a")).To(Equal(3))
+		Expect(bag.Count("b")).To(Equal(2))
+		Expect(bag.Count("missing")).To(Equal(0))
+		Expect(bag.Length()).To(Equal(3))
+		Expect(bag.Total()).To(Equal(6))
+		Expect(bag.Distinct().Slice()).To(ConsistOf("a", "b", "c"))
+		Expect(bag.String()).To(Equal("Multiset[a:3, b:2, c:1]"))
+	})
+	It("adds and removes occurrences", func() {
+		bag.AddN("c", 4)
+		bag.AddN("c", -1)
+		Expect(bag.Count("c")).To(Equal(5))
+		Expect(bag.RemoveN("c", 2)).To(Equal(2))
+		Expect(bag.RemoveN("c", 10)).To(Equal(3))
+		Expect(bag.Contains("c")).To(BeFalse())
+		bag.Remove("a", "missing")
+		Expect(bag.Count("a")).To(Equal(2))
+		Expect(bag.Length()).To(Equal(2))
+	})
+	It("returns the most common elements", func() {
+		Expect(bag.MostCommon(2)).To(Equal([]collection.MultisetEntry[string]{
+			{Element: "a", Count: 3},
+			{Element: "b", Count: 2},
+		}))
+		bag.AddN("c", 1)
+		Expect(bag.MostCommon(0)).To(Equal([]collection.MultisetEntry[string]{
+			{Element: "a", Count: 3},
+			{Element: "b", Count: 2},
+			{Element: "c", Count: 2},
+		}))
+	})
+	It("combines multisets", func() {
+		other := collection.NewMultiset("a", "b", "b", "b", "d")
+		Expect(bag.Union(other).String()).To(Equal("Multiset[a:3, b:3, c:1, d:1]"))
+		Expect(bag.Intersection(other).String()).To(Equal("Multiset[a:1, b:2]"))
+		Expect(bag.Sum(other).String()).To(Equal("Multiset[a:4, b:5, c:1, d:1]"))
+		Expect(bag.Sum(bag).Total()).To(Equal(12))
+		Expect(bag.Total()).To(Equal(6))
+	})
+	It("iterates counts", func() {
+		counts := map[string]int{}
+		err := bag.Each(
+			context.Background(),
+			func(ctx context.Context, element string, count int) error {
+				counts[element] = count
+				return nil
+			},
+		)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(counts).To(Equal(map[string]int{"a": 3, "b": 2, "c": 1}))
+	})
+	It("clones counts", func() {
+		clone := bag.Clone()
+		clone.Add("d")
+		Expect(clone.Count("d")).To(Equal(1))
+		Expect(bag.Count("d")).To(Equal(0))
+	})
+	It("round-trips json as element to count object", func() {
+		data, err := json.Marshal(bag)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"a":3,"b":2,"c":1}`))
+
+		decoded := collection.NewMultiset("x")
+		Expect(json.Unmarshal([]byte(`{"a":3,"b":0,"c":1}`), decoded)).To(Succeed())
+		Expect(decoded.String()).To(Equal("Multiset[a:3, c:1]"))
+	})
+	It("encodes integer elements as json keys", func() {
+		data, err := json.Marshal(collection.NewMultiset(2, 1, 2))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"1":1,"2":2}`))
+	})
+	It("rejects negative counts in json", func() {
+		Expect(json.Unmarshal([]byte(`{"a":-1}`), bag)).NotTo(Succeed())
+		Expect(bag.Total()).To(Equal(6))
+	})
+})
@@ -4,6 +4,8 @@
 
 package collection
 
+import "iter"
+
 // Reverse returns a new slice with the elements in reverse order.
 func Reverse[T any](values []T) []T {
 	length := len(values)
@@ -13,3 +15,16 @@ func Reverse[T any](values []T) []T {
 	}
 	return result
 }
+
+// ReverseSeq returns an iterator over the elements of seq in reverse order.
+// The input sequence is fully consumed before the first element is yielded.
+func ReverseSeq[T any](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		values := Collect(seq)
+		for i := len(values) - 1; i >= 0; i-- {
+			if !yield(values[i]) {
+				return
+			}
+		}
+	}
+}
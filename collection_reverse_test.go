@@ -5,6 +5,8 @@
 package collection_test
 
 import (
+	"slices"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -24,3 +26,10 @@ var _ = Describe("Reverse", func() {
 		Expect(result).To(Equal([]string{"c", "b", "a"}))
 	})
 })
+
+var _ = Describe("ReverseSeq", func() {
+	It("yields elements in reverse order", func() {
+		result := collection.ReverseSeq(slices.Values([]string{"a", "b", "c"}))
+		Expect(slices.Collect(result)).To(Equal([]string{"c", "b", "a"}))
+	})
+})
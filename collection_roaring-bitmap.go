@@ -0,0 +1,482 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"math/bits"
+	"sort"
+
+	"github.com/bborbe/errors"
+)
+
+const (
+	// roaringArrayMax is the largest cardinality stored as a sorted array.
+	// Above it a bitmap of 2^16 bits (8 KiB) is smaller.
+	roaringArrayMax = 4096
+	// roaringBitmapWords is the number of uint64 words of a bitmap container.
+	roaringBitmapWords = 1 << 16 / 64
+)
+
+// roaringBitmap is a compressed bitmap of uint64 values in the style of Roaring bitmaps.
+// Values are grouped by their upper 48 bits into containers holding the lower 16 bits,
+// either as a sorted array for sparse or as a bitmap for dense containers.
+// It is not thread-safe.
+type roaringBitmap struct {
+	containers []*roaringContainer
+	length     int
+}
+
+// roaringContainer holds the lower 16 bits of all values sharing key as upper bits.
+type roaringContainer struct {
+	key         uint64
+	array       []uint16
+	bitmap      []uint64
+	cardinality int
+}
+
+// roaringOp describes a set operation by the values it keeps.
+type roaringOp struct {
+	// keep reports whether a value present in a (inA) and/or b (inB) is part of the result.
+	keep func(inA, inB bool) bool
+	// words combines bitmap words of a and b.
+	words func(a, b uint64) uint64
+}
+
+var (
+	roaringAnd = roaringOp{
+		keep:  func(inA, inB bool) bool { return inA && inB },
+		words: func(a, b uint64) uint64 { return a & b },
+	}
+	roaringOr = roaringOp{
+		keep:  func(inA, inB bool) bool { return inA || inB },
+		words: func(a, b uint64) uint64 { return a | b },
+	}
+	roaringAndNot = roaringOp{
+		keep:  func(inA, inB bool) bool { return inA && !inB },
+		words: func(a, b uint64) uint64 { return a &^ b },
+	}
+	roaringXor = roaringOp{
+		keep:  func(inA, inB bool) bool { return inA != inB },
+		words: func(a, b uint64) uint64 { return a ^ b },
+	}
+)
+
+// find returns the index of the container for key and whether it exists.
+func (r *roaringBitmap) find(key uint64) (int, bool) {
+	i := sort.Search(len(r.containers), func(i int) bool {
+		return r.containers[i].key >= key
+	})
+	return i, i < len(r.containers) && r.containers[i].key == key
+}
+
+func (r *roaringBitmap) contains(value uint64) bool {
+	i, found := r.find(value >> 16)
+	return found && r.containers[i].contains(uint16(value))
+}
+
+// add inserts value and reports whether it was added.
+func (r *roaringBitmap) add(value uint64) bool {
+	i, found := r.find(value >> 16)
+	if !found {
+		r.containers = append(r.containers, nil)
+		copy(r.containers[i+1:], r.containers[i:])
+		r.containers[i] = &roaringContainer{key: value >> 16}
+	}
+	if !r.containers[i].add(uint16(value)) {
+		return false
+	}
+	r.length++
+	return true
+}
+
+// remove deletes value and reports whether it was present.
+func (r *roaringBitmap) remove(value uint64) bool {
+	i, found := r.find(value >> 16)
+	if !found || !r.containers[i].remove(uint16(value)) {
+		return false
+	}
+	if r.containers[i].cardinality == 0 {
+		r.containers = append(r.containers[:i], r.containers[i+1:]...)
+	}
+	r.length--
+	return true
+}
+
+// each calls fn for all values in ascending order until fn returns false.
+func (r *roaringBitmap) each(fn func(value uint64) bool) bool {
+	for _, c := range r.containers {
+		high := c.key << 16
+		if !c.each(func(low uint16) bool { return fn(high | uint64(low)) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// values returns all values in ascending order.
+func (r *roaringBitmap) values() []uint64 {
+	result := make([]uint64, 0, r.length)
+	r.each(func(value uint64) bool {
+		result = append(result, value)
+		return true
+	})
+	return result
+}
+
+func (r *roaringBitmap) clone() roaringBitmap {
+	result := roaringBitmap{
+		containers: make([]*roaringContainer, len(r.containers)),
+		length:     r.length,
+	}
+	for i, c := range r.containers {
+		result.containers[i] = c.clone()
+	}
+	return result
+}
+
+// combineRoaring returns the result of op applied to a and b.
+// Containers are merged by key, so only containers present in both bitmaps are combined.
+func combineRoaring(a, b *roaringBitmap, op roaringOp) roaringBitmap {
+	var result roaringBitmap
+	appendContainer := func(c *roaringContainer) {
+		if c != nil && c.cardinality > 0 {
+			result.containers = append(result.containers, c)
+			result.length += c.cardinality
+		}
+	}
+	keepA, keepB := op.keep(true, false), op.keep(false, true)
+	i, j := 0, 0
+	for i < len(a.containers) || j < len(b.containers) {
+		switch {
+		case j == len(b.containers) ||
+			i < len(a.containers) && a.containers[i].key < b.containers[j].key:
+			if keepA {
+				appendContainer(a.containers[i].clone())
+			}
+			i++
+		case i == len(a.containers) || b.containers[j].key < a.containers[i].key:
+			if keepB {
+				appendContainer(b.containers[j].clone())
+			}
+			j++
+		default:
+			appendContainer(combineContainers(a.containers[i], b.containers[j], op))
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+func (c *roaringContainer) contains(low uint16) bool {
+	if c.bitmap != nil {
+		return c.bitmap[low/64]&(1<<(low%64)) != 0
+	}
+	_, found := c.search(low)
+	return found
+}
+
+// search returns the position of low in the array and whether it is present.
+func (c *roaringContainer) search(low uint16) (int, bool) {
+	i := sort.Search(len(c.array), func(i int) bool { return c.array[i] >= low })
+	return i, i < len(c.array) && c.array[i] == low
+}
+
+func (c *roaringContainer) add(low uint16) bool {
+	if c.bitmap != nil {
+		mask := uint64(1) << (low % 64)
+		if c.bitmap[low/64]&mask != 0 {
+			return false
+		}
+		c.bitmap[low/64] |= mask
+		c.cardinality++
+		return true
+	}
+	i, found := c.search(low)
+	if found {
+		return false
+	}
+	c.array = append(c.array, 0)
+	copy(c.array[i+1:], c.array[i:])
+	c.array[i] = low
+	c.cardinality++
+	if c.cardinality > roaringArrayMax {
+		c.bitmap = c.words()
+		c.array = nil
+	}
+	return true
+}
+
+func (c *roaringContainer) remove(low uint16) bool {
+	if c.bitmap != nil {
+		mask := uint64(1) << (low % 64)
+		if c.bitmap[low/64]&mask == 0 {
+			return false
+		}
+		c.bitmap[low/64] &^= mask
+		c.cardinality--
+		if c.cardinality <= roaringArrayMax {
+			*c = *newRoaringContainerFromWords(c.key, c.bitmap)
+		}
+		return true
+	}
+	i, found := c.search(low)
+	if !found {
+		return false
+	}
+	c.array = append(c.array[:i], c.array[i+1:]...)
+	c.cardinality--
+	return true
+}
+
+// each calls fn for the lower bits of all values in ascending order until fn returns false.
+func (c *roaringContainer) each(fn func(low uint16) bool) bool {
+	if c.bitmap == nil {
+		for _, low := range c.array {
+			if !fn(low) {
+				return false
+			}
+		}
+		return true
+	}
+	for i, word := range c.bitmap {
+		for word != 0 {
+			low := uint16(i*64 + bits.TrailingZeros64(word)) // #nosec G115 -- below 1<<16
+			if !fn(low) {
+				return false
+			}
+			word &= word - 1
+		}
+	}
+	return true
+}
+
+// words returns the content of the container as bitmap words.
+// For bitmap containers the returned slice is a copy.
+func (c *roaringContainer) words() []uint64 {
+	result := make([]uint64, roaringBitmapWords)
+	if c.bitmap != nil {
+		copy(result, c.bitmap)
+		return result
+	}
+	for _, low := range c.array {
+		result[low/64] |= 1 << (low % 64)
+	}
+	return result
+}
+
+func (c *roaringContainer) clone() *roaringContainer {
+	result := &roaringContainer{key: c.key, cardinality: c.cardinality}
+	if c.bitmap != nil {
+		result.bitmap = c.words()
+	} else {
+		result.array = append([]uint16(nil), c.array...)
+	}
+	return result
+}
+
+// newRoaringContainerFromWords creates a container from bitmap words,
+// using an array if the cardinality allows it.
+func newRoaringContainerFromWords(key uint64, words []uint64) *roaringContainer {
+	result := &roaringContainer{key: key}
+	for _, word := range words {
+		result.cardinality += bits.OnesCount64(word)
+	}
+	if result.cardinality > roaringArrayMax {
+		result.bitmap = words
+		return result
+	}
+	result.array = make([]uint16, 0, result.cardinality)
+	full := &roaringContainer{bitmap: words}
+	full.each(func(low uint16) bool {
+		result.array = append(result.array, low)
+		return true
+	})
+	return result
+}
+
+// combineContainers returns the result of op applied to two containers with the same key.
+func combineContainers(a, b *roaringContainer, op roaringOp) *roaringContainer {
+	if a.bitmap == nil && b.bitmap == nil {
+		result := &roaringContainer{key: a.key, array: mergeRoaringArrays(a.array, b.array, op)}
+		result.cardinality = len(result.array)
+		if result.cardinality > roaringArrayMax {
+			return newRoaringContainerFromWords(a.key, result.words())
+		}
+		return result
+	}
+	aw, bw := a.words(), b.words()
+	for i := range aw {
+		aw[i] = op.words(aw[i], bw[i])
+	}
+	return newRoaringContainerFromWords(a.key, aw)
+}
+
+// mergeRoaringArrays merges two sorted arrays keeping the values selected by op.
+func mergeRoaringArrays(a, b []uint16, op roaringOp) []uint16 {
+	result := make([]uint16, 0, max(len(a), len(b)))
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case j == len(b) || i < len(a) && a[i] < b[j]:
+			if op.keep(true, false) {
+				result = append(result, a[i])
+			}
+			i++
+		case i == len(a) || b[j] < a[i]:
+			if op.keep(false, true) {
+				result = append(result, b[j])
+			}
+			j++
+		default:
+			if op.keep(true, true) {
+				result = append(result, a[i])
+			}
+			i++
+			j++
+		}
+	}
+	return result
+}
+
+// The binary roaring bitmap format is
+//
+//	version byte    roaringBinaryVersion
+//	count   uvarint number of containers
+//
+// followed by count containers in ascending key order, each consisting of
+//
+//	key  uvarint upper 48 bits of the values
+//	kind byte    roaringBinaryArray or roaringBinaryBitmap
+//
+// and either a uvarint cardinality followed by the sorted lower 16 bits as little-endian
+// uint16 values, or 1024 little-endian uint64 bitmap words.
+const (
+	roaringBinaryVersion byte = 1
+	roaringBinaryArray   byte = 1
+	roaringBinaryBitmap  byte = 2
+)
+
+func (r *roaringBitmap) marshalBinary() []byte {
+	buf := []byte{roaringBinaryVersion}
+	buf = binary.AppendUvarint(buf, uint64(len(r.containers)))
+	for _, c := range r.containers {
+		buf = binary.AppendUvarint(buf, c.key)
+		if c.bitmap != nil {
+			buf = append(buf, roaringBinaryBitmap)
+			for _, word := range c.bitmap {
+				buf = binary.LittleEndian.AppendUint64(buf, word)
+			}
+			continue
+		}
+		buf = append(buf, roaringBinaryArray)
+		buf = binary.AppendUvarint(buf, uint64(len(c.array)))
+		for _, low := range c.array {
+			buf = binary.LittleEndian.AppendUint16(buf, low)
+		}
+	}
+	return buf
+}
+
+func unmarshalRoaringBitmap(data []byte) (roaringBitmap, error) {
+	ctx := context.Background()
+	if len(data) < 1 || data[0] != roaringBinaryVersion {
+		return roaringBitmap{}, errors.New(ctx, "unsupported binary bitmap version")
+	}
+	reader := bytes.NewReader(data[1:])
+	count, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return roaringBitmap{}, errors.Wrapf(ctx, err, "read container count failed")
+	}
+	// every container needs at least two bytes, which bounds the allocation for corrupt input
+	if count > uint64(reader.Len())/2 {
+		return roaringBitmap{}, errors.Errorf(ctx, "invalid container count %d", count)
+	}
+	var result roaringBitmap
+	for i := uint64(0); i < count; i++ {
+		c, err := readRoaringContainer(ctx, reader)
+		if err != nil {
+			return roaringBitmap{}, errors.Wrapf(ctx, err, "read container %d failed", i)
+		}
+		if n := len(result.containers); n > 0 && result.containers[n-1].key >= c.key {
+			return roaringBitmap{}, errors.Errorf(ctx, "container keys not ascending at %d", i)
+		}
+		result.containers = append(result.containers, c)
+		result.length += c.cardinality
+	}
+	if reader.Len() > 0 {
+		return roaringBitmap{}, errors.Errorf(ctx, "%d trailing bytes in bitmap", reader.Len())
+	}
+	return result, nil
+}
+
+func readRoaringContainer(ctx context.Context, reader *bytes.Reader) (*roaringContainer, error) {
+	key, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, errors.Wrapf(ctx, err, "read key failed")
+	}
+	if key > 1<<48-1 {
+		return nil, errors.Errorf(ctx, "invalid key %d", key)
+	}
+	kind, err := reader.ReadByte()
+	if err != nil {
+		return nil, errors.Wrapf(ctx, err, "read kind failed")
+	}
+	var words []uint64
+	switch kind {
+	case roaringBinaryArray:
+		words, err = readRoaringArray(ctx, reader)
+	case roaringBinaryBitmap:
+		words, err = readRoaringWords(ctx, reader)
+	default:
+		return nil, errors.Errorf(ctx, "unsupported container kind %d", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	result := newRoaringContainerFromWords(key, words)
+	if result.cardinality == 0 {
+		return nil, errors.New(ctx, "empty container")
+	}
+	return result, nil
+}
+
+// readRoaringArray reads a sorted array container and returns it as bitmap words.
+func readRoaringArray(ctx context.Context, reader *bytes.Reader) ([]uint64, error) {
+	n, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, errors.Wrapf(ctx, err, "read cardinality failed")
+	}
+	if n > uint64(reader.Len())/2 {
+		return nil, errors.Errorf(ctx, "invalid cardinality %d", n)
+	}
+	words := make([]uint64, roaringBitmapWords)
+	var buf [2]byte
+	for i := uint64(0); i < n; i++ {
+		_, _ = reader.Read(buf[:])
+		low := binary.LittleEndian.Uint16(buf[:])
+		if words[low/64]&(1<<(low%64)) != 0 {
+			return nil, errors.Errorf(ctx, "duplicate value %d", low)
+		}
+		words[low/64] |= 1 << (low % 64)
+	}
+	return words, nil
+}
+
+func readRoaringWords(ctx context.Context, reader *bytes.Reader) ([]uint64, error) {
+	if reader.Len() < roaringBitmapWords*8 {
+		return nil, errors.New(ctx, "truncated bitmap container")
+	}
+	words := make([]uint64, roaringBitmapWords)
+	var buf [8]byte
+	for i := range words {
+		_, _ = reader.Read(buf[:])
+		words[i] = binary.LittleEndian.Uint64(buf[:])
+	}
+	return words, nil
+}
@@ -0,0 +1,300 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// setIDCounter hands out the lock order for set instances.
+var setIDCounter atomic.Uint64
+
+// orderedLocker is implemented by set types that can take part in
+// multi-set operations. Locks are always acquired in ascending lockOrder,
+// so two goroutines combining the same sets cannot deadlock.
+type orderedLocker interface {
+	lockOrder() uint64
+	lock()
+	unlock()
+}
+
+// lockedSet is an orderedLocker that can be read while its lock is held.
+type lockedSet[T any] interface {
+	orderedLocker
+	containsLocked(element T) bool
+	sliceLocked() []T
+}
+
+// setLockID returns the lock order stored in id, assigning a new one on first use.
+// This keeps zero-value sets (e.g. created by decoders) safe to lock in order.
+func setLockID(id *atomic.Uint64) uint64 {
+	if current := id.Load(); current != 0 {
+		return current
+	}
+	id.CompareAndSwap(0, setIDCounter.Add(1))
+	return id.Load()
+}
+
+// lockOrdered locks all given lockers in ascending lock order and returns
+// a function that releases them again. A locker passed multiple times is only locked once.
+func lockOrdered[L orderedLocker](lockers ...L) func() {
+	ordered := make([]L, len(lockers))
+	copy(ordered, lockers)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].lockOrder() < ordered[j].lockOrder()
+	})
+
+	locked := make([]L, 0, len(ordered))
+	for i, locker := range ordered {
+		if i > 0 && ordered[i-1].lockOrder() == locker.lockOrder() {
+			continue
+		}
+		locker.lock()
+		locked = append(locked, locker)
+	}
+	return func() {
+		for i := len(locked) - 1; i >= 0; i-- {
+			locked[i].unlock()
+		}
+	}
+}
+
+// unionLocked returns all elements of all sets. The result may contain duplicates.
+func unionLocked[T any, S lockedSet[T]](sets []S) []T {
+	var result []T
+	for _, s := range sets {
+		result = append(result, s.sliceLocked()...)
+	}
+	return result
+}
+
+// intersectionLocked returns the elements of the first set that are present in all other sets.
+func intersectionLocked[T any, S lockedSet[T]](sets []S) []T {
+	if len(sets) == 0 {
+		return nil
+	}
+	var result []T
+	for _, element := range sets[0].sliceLocked() {
+		if containedInAll(element, sets[1:]) {
+			result = append(result, element)
+		}
+	}
+	return result
+}
+
+// differenceLocked returns the elements of the first set that are not present in any other set.
+func differenceLocked[T any, S lockedSet[T]](sets []S) []T {
+	if len(sets) == 0 {
+		return nil
+	}
+	var result []T
+	for _, element := range sets[0].sliceLocked() {
+		if !containedInAny(element, sets[1:]) {
+			result = append(result, element)
+		}
+	}
+	return result
+}
+
+// symmetricDifferenceLocked returns the elements present in an odd number of sets.
+// For two sets these are the elements present in exactly one of them.
+// The result may contain duplicates.
+func symmetricDifferenceLocked[T any, S lockedSet[T]](sets []S) []T {
+	var result []T
+	for _, s := range sets {
+		for _, element := range s.sliceLocked() {
+			count := 0
+			for _, other := range sets {
+				if other.containsLocked(element) {
+					count++
+				}
+			}
+			if count%2 == 1 {
+				result = append(result, element)
+			}
+		}
+	}
+	return result
+}
+
+// isSubsetLocked reports whether all elements of a are present in b.
+func isSubsetLocked[T any, S lockedSet[T]](a S, b S) bool {
+	for _, element := range a.sliceLocked() {
+		if !b.containsLocked(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// isDisjointLocked reports whether a and b have no element in common.
+func isDisjointLocked[T any, S lockedSet[T]](a S, b S) bool {
+	for _, element := range a.sliceLocked() {
+		if b.containsLocked(element) {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAll[T any, S lockedSet[T]](element T, sets []S) bool {
+	for _, s := range sets {
+		if !s.containsLocked(element) {
+			return false
+		}
+	}
+	return true
+}
+
+func containedInAny[T any, S lockedSet[T]](element T, sets []S) bool {
+	for _, s := range sets {
+		if s.containsLocked(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetUnion returns a new Set containing all elements present in at least one of the given sets.
+func SetUnion[T comparable](sets ...Set[T]) Set[T] {
+	concrete := toLockedSets(sets)
+	defer lockOrdered(concrete...)()
+	return NewSet(unionLocked[T](concrete)...)
+}
+
+// SetIntersection returns a new Set containing the elements present in all given sets.
+// It returns an empty set if no sets are given.
+func SetIntersection[T comparable](sets ...Set[T]) Set[T] {
+	concrete := toLockedSets(sets)
+	defer lockOrdered(concrete...)()
+	return NewSet(intersectionLocked[T](concrete)...)
+}
+
+// SetDifference returns a new Set containing the elements of the first set
+// that are not present in any of the other sets.
+// It returns an empty set if no sets are given.
+func SetDifference[T comparable](sets ...Set[T]) Set[T] {
+	concrete := toLockedSets(sets)
+	defer lockOrdered(concrete...)()
+	return NewSet(differenceLocked[T](concrete)...)
+}
+
+// SetSymmetricDifference returns a new Set containing the elements present
+// in an odd number of the given sets. For two sets this is the set of elements
+// present in exactly one of them.
+func SetSymmetricDifference[T comparable](sets ...Set[T]) Set[T] {
+	concrete := toLockedSets(sets)
+	defer lockOrdered(concrete...)()
+	return NewSet(symmetricDifferenceLocked[T](concrete)...)
+}
+
+// SetHashCodeUnion returns a new SetHashCode containing all elements present in at least one of the given sets.
+// If sets contain different elements with the same hash code, the element of the last set wins.
+func SetHashCodeUnion[T HasHashCode](sets ...SetHashCode[T]) SetHashCode[T] {
+	concrete := toSetHashCodes(sets)
+	defer lockOrdered(concrete...)()
+	return NewSetHashCode(unionLocked[T](concrete)...)
+}
+
+// SetHashCodeIntersection returns a new SetHashCode containing the elements present in all given sets.
+// It returns an empty set if no sets are given.
+func SetHashCodeIntersection[T HasHashCode](sets ...SetHashCode[T]) SetHashCode[T] {
+	concrete := toSetHashCodes(sets)
+	defer lockOrdered(concrete...)()
+	return NewSetHashCode(intersectionLocked[T](concrete)...)
+}
+
+// SetHashCodeDifference returns a new SetHashCode containing the elements of the first set
+// that are not present in any of the other sets.
+// It returns an empty set if no sets are given.
+func SetHashCodeDifference[T HasHashCode](sets ...SetHashCode[T]) SetHashCode[T] {
+	concrete := toSetHashCodes(sets)
+	defer lockOrdered(concrete...)()
+	return NewSetHashCode(differenceLocked[T](concrete)...)
+}
+
+// SetHashCodeSymmetricDifference returns a new SetHashCode containing the elements present
+// in an odd number of the given sets.
+func SetHashCodeSymmetricDifference[T HasHashCode](sets ...SetHashCode[T]) SetHashCode[T] {
+	concrete := toSetHashCodes(sets)
+	defer lockOrdered(concrete...)()
+	return NewSetHashCode(symmetricDifferenceLocked[T](concrete)...)
+}
+
+// SetEqualUnion returns a new SetEqual containing all elements present in at least one of the given sets.
+// Elements keep their insertion order, starting with the elements of the first set.
+func SetEqualUnion[T HasEqual[T]](sets ...SetEqual[T]) SetEqual[T] {
+	concrete := toSetEquals(sets)
+	defer lockOrdered(concrete...)()
+	return NewSetEqual(unionLocked[T](concrete)...)
+}
+
+// SetEqualIntersection returns a new SetEqual containing the elements present in all given sets.
+// Elements keep the insertion order of the first set.
+func SetEqualIntersection[T HasEqual[T]](sets ...SetEqual[T]) SetEqual[T] {
+	concrete := toSetEquals(sets)
+	defer lockOrdered(concrete...)()
+	return NewSetEqual(intersectionLocked[T](concrete)...)
+}
+
+// SetEqualDifference returns a new SetEqual containing the elements of the first set
+// that are not present in any of the other sets.
+// Elements keep the insertion order of the first set.
+func SetEqualDifference[T HasEqual[T]](sets ...SetEqual[T]) SetEqual[T] {
+	concrete := toSetEquals(sets)
+	defer lockOrdered(concrete...)()
+	return NewSetEqual(differenceLocked[T](concrete)...)
+}
+
+// SetEqualSymmetricDifference returns a new SetEqual containing the elements present
+// in an odd number of the given sets.
+func SetEqualSymmetricDifference[T HasEqual[T]](sets ...SetEqual[T]) SetEqual[T] {
+	concrete := toSetEquals(sets)
+	defer lockOrdered(concrete...)()
+	return NewSetEqual(symmetricDifferenceLocked[T](concrete)...)
+}
+
+// toLockedSets converts the given sets to lockable views.
+// Foreign implementations are copied into a new internal set.
+func toLockedSets[T comparable](sets []Set[T]) []lockedSet[T] {
+	result := make([]lockedSet[T], 0, len(sets))
+	for _, s := range sets {
+		if locked, ok := s.(lockedSet[T]); ok {
+			result = append(result, locked)
+			continue
+		}
+		result = append(result, newSet(s.Slice()...))
+	}
+	return result
+}
+
+// toSetHashCodes converts the given sets to the internal implementation.
+// Foreign implementations are copied into a new internal set.
+func toSetHashCodes[T HasHashCode](sets []SetHashCode[T]) []*setHashCode[T] {
+	result := make([]*setHashCode[T], 0, len(sets))
+	for _, s := range sets {
+		if concrete, ok := s.(*setHashCode[T]); ok {
+			result = append(result, concrete)
+			continue
+		}
+		result = append(result, newSetHashCode(s.Slice()...))
+	}
+	return result
+}
+
+// toSetEquals converts the given sets to the internal implementation.
+// Foreign implementations are copied into a new internal set.
+func toSetEquals[T HasEqual[T]](sets []SetEqual[T]) []*setEqual[T] {
+	result := make([]*setEqual[T], 0, len(sets))
+	for _, s := range sets {
+		if concrete, ok := s.(*setEqual[T]); ok {
+			result = append(result, concrete)
+			continue
+		}
+		result = append(result, newSetEqual(s.Slice()...))
+	}
+	return result
+}
@@ -0,0 +1,241 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"bytes"
+	"context"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"math"
+	"reflect"
+
+	"github.com/bborbe/errors"
+)
+
+// The binary set format is
+//
+//	version  byte    setBinaryVersion
+//	encoding byte    setBinaryElements or setBinaryGob
+//	count    uvarint number of elements
+//
+// followed by count length-prefixed (uvarint) elements for setBinaryElements,
+// or a single length-prefixed gob stream containing count elements for setBinaryGob.
+const (
+	setBinaryVersion byte = 1
+
+	// setBinaryElements stores each element with encoding.BinaryMarshaler or,
+	// for types based on string, bool, int, uint and float, in a compact built-in form.
+	setBinaryElements byte = 1
+	// setBinaryGob stores all elements in one gob stream, so type information
+	// is written only once.
+	setBinaryGob byte = 2
+)
+
+// marshalSetBinary encodes elements into the versioned binary set format.
+func marshalSetBinary[T any](elements []T) ([]byte, error) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	buf.WriteByte(setBinaryVersion)
+	if !hasElementBinaryCodec[T]() {
+		buf.WriteByte(setBinaryGob)
+		buf.Write(binary.AppendUvarint(nil, uint64(len(elements))))
+		var stream bytes.Buffer
+		encoder := gob.NewEncoder(&stream)
+		for _, element := range elements {
+			if err := encoder.Encode(element); err != nil {
+				return nil, errors.Wrapf(ctx, err, "gob encode element failed")
+			}
+		}
+		buf.Write(binary.AppendUvarint(nil, uint64(stream.Len())))
+		buf.Write(stream.Bytes())
+		return buf.Bytes(), nil
+	}
+	buf.WriteByte(setBinaryElements)
+	buf.Write(binary.AppendUvarint(nil, uint64(len(elements))))
+	for _, element := range elements {
+		data, err := marshalElementBinary(element)
+		if err != nil {
+			return nil, errors.Wrapf(ctx, err, "marshal element failed")
+		}
+		buf.Write(binary.AppendUvarint(nil, uint64(len(data))))
+		buf.Write(data)
+	}
+	return buf.Bytes(), nil
+}
+
+// unmarshalSetBinary decodes elements from the versioned binary set format.
+func unmarshalSetBinary[T any](data []byte) ([]T, error) {
+	ctx := context.Background()
+	if len(data) < 2 {
+		return nil, errors.New(ctx, "binary set data too short")
+	}
+	if data[0] != setBinaryVersion {
+		return nil, errors.Errorf(ctx, "unsupported binary set version %d", data[0])
+	}
+	format := data[1]
+	reader := bytes.NewReader(data[2:])
+	count, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, errors.Wrapf(ctx, err, "read element count failed")
+	}
+	// every element needs at least one byte, which bounds the allocation for corrupt input
+	if count > uint64(reader.Len()) {
+		return nil, errors.Errorf(ctx, "invalid element count %d", count)
+	}
+	switch format {
+	case setBinaryElements:
+		return unmarshalSetBinaryElements[T](ctx, reader, count)
+	case setBinaryGob:
+		return unmarshalSetBinaryGob[T](ctx, reader, count)
+	default:
+		return nil, errors.Errorf(ctx, "unsupported binary set encoding %d", format)
+	}
+}
+
+func unmarshalSetBinaryElements[T any](
+	ctx context.Context,
+	reader *bytes.Reader,
+	count uint64,
+) ([]T, error) {
+	result := make([]T, 0, count)
+	for i := uint64(0); i < count; i++ {
+		data, err := readLengthPrefixed(ctx, reader)
+		if err != nil {
+			return nil, err
+		}
+		element, err := unmarshalElementBinary[T](data)
+		if err != nil {
+			return nil, errors.Wrapf(ctx, err, "unmarshal element %d failed", i)
+		}
+		result = append(result, element)
+	}
+	if reader.Len() > 0 {
+		return nil, errors.Errorf(ctx, "%d trailing bytes in binary set", reader.Len())
+	}
+	return result, nil
+}
+
+func unmarshalSetBinaryGob[T any](
+	ctx context.Context,
+	reader *bytes.Reader,
+	count uint64,
+) ([]T, error) {
+	stream, err := readLengthPrefixed(ctx, reader)
+	if err != nil {
+		return nil, err
+	}
+	if reader.Len() > 0 {
+		return nil, errors.Errorf(ctx, "%d trailing bytes in binary set", reader.Len())
+	}
+	decoder := gob.NewDecoder(bytes.NewReader(stream))
+	result := make([]T, 0, count)
+	for i := uint64(0); i < count; i++ {
+		var element T
+		if err := decoder.Decode(&element); err != nil {
+			return nil, errors.Wrapf(ctx, err, "gob decode element %d failed", i)
+		}
+		result = append(result, element)
+	}
+	return result, nil
+}
+
+func readLengthPrefixed(ctx context.Context, reader *bytes.Reader) ([]byte, error) {
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return nil, errors.Wrapf(ctx, err, "read length failed")
+	}
+	if length > uint64(reader.Len()) {
+		return nil, errors.Errorf(ctx, "length %d exceeds remaining %d bytes", length, reader.Len())
+	}
+	data := make([]byte, length)
+	if _, err := reader.Read(data); err != nil && length > 0 {
+		return nil, errors.Wrapf(ctx, err, "read data failed")
+	}
+	return data, nil
+}
+
+// hasElementBinaryCodec reports whether elements of type T can be encoded one by one.
+func hasElementBinaryCodec[T any]() bool {
+	var element T
+	_, marshaler := any(element).(encoding.BinaryMarshaler)
+	_, unmarshaler := any(&element).(encoding.BinaryUnmarshaler)
+	if marshaler && unmarshaler {
+		return true
+	}
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func marshalElementBinary[T any](element T) ([]byte, error) {
+	if marshaler, ok := any(element).(encoding.BinaryMarshaler); ok {
+		return marshaler.MarshalBinary()
+	}
+	value := reflect.ValueOf(element)
+	switch value.Kind() {
+	case reflect.String:
+		return []byte(value.String()), nil
+	case reflect.Bool:
+		if value.Bool() {
+			return []byte{1}, nil
+		}
+		return []byte{0}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return binary.AppendVarint(nil, value.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return binary.AppendUvarint(nil, value.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return binary.LittleEndian.AppendUint64(nil, math.Float64bits(value.Float())), nil
+	default:
+		return nil, errors.Errorf(context.Background(), "unsupported element type %T", element)
+	}
+}
+
+func unmarshalElementBinary[T any](data []byte) (T, error) {
+	ctx := context.Background()
+	var result T
+	if unmarshaler, ok := any(&result).(encoding.BinaryUnmarshaler); ok {
+		err := unmarshaler.UnmarshalBinary(data)
+		return result, err
+	}
+	value := reflect.ValueOf(&result).Elem()
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(string(data))
+	case reflect.Bool:
+		if len(data) != 1 || data[0] > 1 {
+			return result, errors.New(ctx, "invalid bool")
+		}
+		value.SetBool(data[0] == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, n := binary.Varint(data)
+		if n != len(data) || value.OverflowInt(i) {
+			return result, errors.New(ctx, "invalid int")
+		}
+		value.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, n := binary.Uvarint(data)
+		if n != len(data) || value.OverflowUint(u) {
+			return result, errors.New(ctx, "invalid uint")
+		}
+		value.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		if len(data) != 8 {
+			return result, errors.New(ctx, "invalid float")
+		}
+		value.SetFloat(math.Float64frombits(binary.LittleEndian.Uint64(data)))
+	default:
+		return result, errors.Errorf(ctx, "unsupported element type %T", result)
+	}
+	return result, nil
+}
@@ -0,0 +1,501 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/bborbe/errors"
+)
+
+// BitmapElement is the constraint for elements of a BitmapSet.
+type BitmapElement interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// BitmapSet represents a thread-safe set of integers stored in a compressed bitmap.
+// It implements Set, so it can replace a Set of integers, and keeps its elements in
+// ascending order: Each, Slice, All, Strings, MarshalJSON and MarshalText are sorted.
+//
+// Performance: Elements are grouped by their upper bits into containers of 2^16 values,
+// stored as sorted arrays if sparse and as bitmaps if dense. Dense integer IDs take
+// about one bit each, and And, Or, AndNot and Xor combine whole containers at once.
+type BitmapSet[T BitmapElement] interface {
+	Set[T]
+	// And returns a new BitmapSet containing the elements present in both sets.
+	And(other BitmapSet[T]) BitmapSet[T]
+	// Or returns a new BitmapSet containing the elements present in either set.
+	Or(other BitmapSet[T]) BitmapSet[T]
+	// AndNot returns a new BitmapSet containing the elements of the current set
+	// not present in other.
+	AndNot(other BitmapSet[T]) BitmapSet[T]
+	// Xor returns a new BitmapSet containing the elements present in exactly one of the sets.
+	Xor(other BitmapSet[T]) BitmapSet[T]
+}
+
+// NewBitmapSet creates a new thread-safe set of integers stored in a compressed bitmap.
+// It accepts optional initial elements to populate the set.
+//
+// Example:
+//
+//	ids := collection.NewBitmapSet[uint32](1, 2, 3, 100000)
+//	ids.And(collection.NewBitmapSet[uint32](2, 3)).Slice() // [2 3]
+func NewBitmapSet[T BitmapElement](elements ...T) BitmapSet[T] {
+	return newBitmapSet(elements...)
+}
+
+func newBitmapSet[T BitmapElement](elements ...T) *bitmapSet[T] {
+	s := &bitmapSet[T]{}
+	s.Add(elements...)
+	return s
+}
+
+type bitmapSet[T BitmapElement] struct {
+	id       atomic.Uint64
+	mux      sync.Mutex
+	bitmap   roaringBitmap
+	notifier setNotifier[T]
+}
+
+// bitmapValue maps element to a uint64 with the same order,
+// shifting signed values so negative numbers sort first.
+func bitmapValue[T BitmapElement](element T) uint64 {
+	var zero T
+	if ^zero < 0 {
+		return uint64(int64(element)) ^ 1<<63
+	}
+	return uint64(element)
+}
+
+// bitmapElement is the inverse of bitmapValue.
+func bitmapElement[T BitmapElement](value uint64) T {
+	var zero T
+	if ^zero < 0 {
+		return T(int64(value ^ 1<<63))
+	}
+	return T(value)
+}
+
+// toBitmapSet returns other as bitmapSet, copying other if it is another implementation.
+func toBitmapSet[T BitmapElement](other Set[T]) *bitmapSet[T] {
+	if b, ok := other.(*bitmapSet[T]); ok {
+		return b
+	}
+	return newBitmapSet(other.Slice()...)
+}
+
+func (s *bitmapSet[T]) lockOrder() uint64 {
+	return setLockID(&s.id)
+}
+
+func (s *bitmapSet[T]) lock() {
+	s.mux.Lock()
+}
+
+func (s *bitmapSet[T]) unlock() {
+	s.mux.Unlock()
+}
+
+// releaseLocked unlocks the set and delivers the events to the subscribers.
+func (s *bitmapSet[T]) releaseLocked(events []SetEvent[T]) {
+	s.notifier.release(&s.mux, events...)
+}
+
+func (s *bitmapSet[T]) containsLocked(element T) bool {
+	return s.bitmap.contains(bitmapValue(element))
+}
+
+func (s *bitmapSet[T]) lengthLocked() int {
+	return s.bitmap.length
+}
+
+func (s *bitmapSet[T]) sliceLocked() []T {
+	return bitmapElements[T](&s.bitmap)
+}
+
+// bitmapElements returns all values of bitmap as elements in ascending order.
+func bitmapElements[T BitmapElement](bitmap *roaringBitmap) []T {
+	result := make([]T, 0, bitmap.length)
+	bitmap.each(func(value uint64) bool {
+		result = append(result, bitmapElement[T](value))
+		return true
+	})
+	return result
+}
+
+func (s *bitmapSet[T]) Add(elements ...T) {
+	s.mux.Lock()
+	s.notifier.release(&s.mux, s.addLocked(elements)...)
+}
+
+// addLocked inserts elements and returns the event for the subscribers.
+func (s *bitmapSet[T]) addLocked(elements []T) []SetEvent[T] {
+	track := s.notifier.hasSubscribers()
+	var added []T
+	for _, element := range elements {
+		if s.bitmap.add(bitmapValue(element)) && track {
+			added = append(added, element)
+		}
+	}
+	return []SetEvent[T]{{Type: SetEventAdded, Elements: added}}
+}
+
+func (s *bitmapSet[T]) Remove(elements ...T) {
+	s.mux.Lock()
+	s.notifier.release(&s.mux, s.removeLocked(elements)...)
+}
+
+// removeLocked deletes elements and returns the event for the subscribers.
+func (s *bitmapSet[T]) removeLocked(elements []T) []SetEvent[T] {
+	track := s.notifier.hasSubscribers()
+	var removed []T
+	for _, element := range elements {
+		if s.bitmap.remove(bitmapValue(element)) && track {
+			removed = append(removed, element)
+		}
+	}
+	return []SetEvent[T]{{Type: SetEventRemoved, Elements: removed}}
+}
+
+// replace swaps the content of the set for bitmap and reports the changes to subscribers.
+func (s *bitmapSet[T]) replace(bitmap roaringBitmap) {
+	s.mux.Lock()
+	old := s.bitmap
+	s.bitmap = bitmap
+	if !s.notifier.hasSubscribers() {
+		s.mux.Unlock()
+		return
+	}
+	removed := combineRoaring(&old, &s.bitmap, roaringAndNot)
+	added := combineRoaring(&s.bitmap, &old, roaringAndNot)
+	s.notifier.release(
+		&s.mux,
+		SetEvent[T]{Type: SetEventRemoved, Elements: bitmapElements[T](&removed)},
+		SetEvent[T]{Type: SetEventAdded, Elements: bitmapElements[T](&added)},
+	)
+}
+
+// replaceElements swaps the content of the set for elements.
+func (s *bitmapSet[T]) replaceElements(elements []T) {
+	s.replace(newBitmapSet(elements...).bitmap)
+}
+
+func (s *bitmapSet[T]) Update(fn func(tx SetTx[T]) error) error {
+	return updateSet(s, sameElement[T], nil, fn)
+}
+
+func (s *bitmapSet[T]) AddIfAbsent(element T) bool {
+	return addIfAbsent(s.Update, element)
+}
+
+func (s *bitmapSet[T]) RemoveIfPresent(element T) bool {
+	return removeIfPresent(s.Update, element)
+}
+
+func (s *bitmapSet[T]) Replace(oldElement T, newElement T) bool {
+	return replaceElement(s.Update, oldElement, newElement)
+}
+
+func (s *bitmapSet[T]) CompareAndSwap(expected []T, elements []T) bool {
+	return compareAndSwap(s.Update, expected, elements)
+}
+
+// Subscribe returns a channel that receives an event for every change of the set.
+// Only elements that were actually added or removed are reported.
+// The channel is closed once ctx is canceled.
+func (s *bitmapSet[T]) Subscribe(
+	ctx context.Context,
+	options SubscribeOptions,
+) <-chan SetEvent[T] {
+	return s.notifier.subscribe(ctx, options)
+}
+
+func (s *bitmapSet[T]) Contains(element T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.containsLocked(element)
+}
+
+func (s *bitmapSet[T]) ContainsAll(elements ...T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, element := range elements {
+		if !s.containsLocked(element) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *bitmapSet[T]) ContainsAny(elements ...T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, element := range elements {
+		if s.containsLocked(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// Slice returns all elements in ascending order.
+func (s *bitmapSet[T]) Slice() []T {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.sliceLocked()
+}
+
+// Length returns the number of elements in the set (its cardinality).
+func (s *bitmapSet[T]) Length() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.bitmap.length
+}
+
+// Strings returns all elements as their string representations in ascending element order.
+func (s *bitmapSet[T]) Strings() []string {
+	elements := s.Slice()
+	result := make([]string, 0, len(elements))
+	for _, element := range elements {
+		result = append(result, elementToString(element))
+	}
+	return result
+}
+
+// String returns a human-readable string representation of the set.
+// Format: "BitmapSet[element1, element2, ...]" in ascending order.
+func (s *bitmapSet[T]) String() string {
+	return formatSetString("BitmapSet[", s.Strings())
+}
+
+// Each calls fn for each element of a snapshot of the set. Iteration stops on first error.
+// fn may access and change the set; changes are not reflected in the iteration.
+// Elements are iterated in ascending order.
+func (s *bitmapSet[T]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return Each(ctx, s.Slice(), fn)
+}
+
+// EachLocked calls fn for each element while holding the lock of the set, so the set
+// can't change during the iteration. fn must not call methods of the set, which would
+// deadlock, and blocks all other callers while it runs.
+// Elements are iterated in ascending order.
+func (s *bitmapSet[T]) EachLocked(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	var err error
+	s.bitmap.each(func(value uint64) bool {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+		default:
+			err = fn(ctx, bitmapElement[T](value))
+		}
+		return err == nil
+	})
+	return err
+}
+
+// EachParallel calls fn for each element of a snapshot of the set using up to workers
+// goroutines. The first error cancels the remaining calls and is returned.
+func (s *bitmapSet[T]) EachParallel(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, false, fn)
+}
+
+// EachParallelCollectErrors calls fn for each element of a snapshot of the set using up to
+// workers goroutines and returns the errors of all failed calls joined.
+func (s *bitmapSet[T]) EachParallelCollectErrors(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, true, fn)
+}
+
+// All returns an iterator over a snapshot of the elements in ascending order.
+// Changes to the set during iteration are not reflected.
+func (s *bitmapSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range s.Slice() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a new BitmapSet containing all elements from the current set.
+// The returned set is a shallow copy - modifications to it won't affect the original.
+func (s *bitmapSet[T]) Clone() Set[T] {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return &bitmapSet[T]{bitmap: s.bitmap.clone()}
+}
+
+// Without returns a new BitmapSet containing all elements from the current set
+// except those specified in the elements parameter.
+// The original set is not modified.
+func (s *bitmapSet[T]) Without(elements ...T) Set[T] {
+	result := s.Clone()
+	result.Remove(elements...)
+	return result
+}
+
+// combine returns a new bitmapSet with op applied to the current set and other.
+func (s *bitmapSet[T]) combine(other Set[T], op roaringOp) *bitmapSet[T] {
+	o := toBitmapSet(other)
+	defer lockOrdered(s, o)()
+	return &bitmapSet[T]{bitmap: combineRoaring(&s.bitmap, &o.bitmap, op)}
+}
+
+func (s *bitmapSet[T]) And(other BitmapSet[T]) BitmapSet[T] {
+	return s.combine(other, roaringAnd)
+}
+
+func (s *bitmapSet[T]) Or(other BitmapSet[T]) BitmapSet[T] {
+	return s.combine(other, roaringOr)
+}
+
+func (s *bitmapSet[T]) AndNot(other BitmapSet[T]) BitmapSet[T] {
+	return s.combine(other, roaringAndNot)
+}
+
+func (s *bitmapSet[T]) Xor(other BitmapSet[T]) BitmapSet[T] {
+	return s.combine(other, roaringXor)
+}
+
+// Union returns a new BitmapSet containing all elements of the current set and other.
+func (s *bitmapSet[T]) Union(other Set[T]) Set[T] {
+	return s.combine(other, roaringOr)
+}
+
+// Intersection returns a new BitmapSet containing the elements present in both
+// the current set and other.
+func (s *bitmapSet[T]) Intersection(other Set[T]) Set[T] {
+	return s.combine(other, roaringAnd)
+}
+
+// Difference returns a new BitmapSet containing the elements of the current set
+// not present in other.
+func (s *bitmapSet[T]) Difference(other Set[T]) Set[T] {
+	return s.combine(other, roaringAndNot)
+}
+
+// SymmetricDifference returns a new BitmapSet containing the elements present in
+// exactly one of the current set and other.
+func (s *bitmapSet[T]) SymmetricDifference(other Set[T]) Set[T] {
+	return s.combine(other, roaringXor)
+}
+
+// IsSubsetOf reports whether all elements of the current set are present in other.
+func (s *bitmapSet[T]) IsSubsetOf(other Set[T]) bool {
+	return s.combine(other, roaringAndNot).bitmap.length == 0
+}
+
+// IsSupersetOf reports whether all elements of other are present in the current set.
+func (s *bitmapSet[T]) IsSupersetOf(other Set[T]) bool {
+	return toBitmapSet(other).combine(s, roaringAndNot).bitmap.length == 0
+}
+
+// IsDisjoint reports whether the current set and other have no elements in common.
+func (s *bitmapSet[T]) IsDisjoint(other Set[T]) bool {
+	return s.combine(other, roaringAnd).bitmap.length == 0
+}
+
+// MarshalText implements encoding.TextMarshaler for BitmapSet.
+// Elements are written in ascending order.
+func (s *bitmapSet[T]) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(s.Strings(), ",")), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for BitmapSet.
+func (s *bitmapSet[T]) UnmarshalText(text []byte) error {
+	parts := splitText(string(text))
+	elements := make([]T, 0, len(parts))
+	for _, part := range parts {
+		element, err := parseTextElement[T](part)
+		if err != nil {
+			return err
+		}
+		elements = append(elements, element)
+	}
+
+	s.replaceElements(elements)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for BitmapSet.
+// It serializes the set as a JSON array of elements in ascending order.
+func (s *bitmapSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Slice())
+}
+
+// UnmarshalJSON implements json.Unmarshaler for BitmapSet.
+func (s *bitmapSet[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	s.replaceElements(elements)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler for BitmapSet.
+// Unlike the other sets it writes the compressed containers, so dense sets stay small.
+func (s *bitmapSet[T]) MarshalBinary() ([]byte, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.bitmap.marshalBinary(), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for BitmapSet.
+// The set is left unchanged if data is invalid.
+func (s *bitmapSet[T]) UnmarshalBinary(data []byte) error {
+	bitmap, err := unmarshalRoaringBitmap(data)
+	if err != nil {
+		return err
+	}
+	valid := bitmap.each(func(value uint64) bool {
+		return bitmapValue(bitmapElement[T](value)) == value
+	})
+	if !valid {
+		return errors.Errorf(context.Background(), "binary bitmap exceeds range of %T", *new(T))
+	}
+	s.replace(bitmap)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder for BitmapSet using the binary format.
+func (s *bitmapSet[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder for BitmapSet using the binary format.
+func (s *bitmapSet[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
@@ -0,0 +1,510 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// BoundedSet represents a thread-safe set of comparable elements holding at most Capacity elements.
+// Adding a new element to a full set evicts the least recently used element.
+// Add and Contains mark elements as recently used. Each, Slice, All, MarshalJSON and MarshalText
+// return elements from the least to the most recently used.
+// Evicted elements are reported to subscribers as SetEventRemoved.
+//
+// Performance: This implementation combines a map with a doubly linked list and provides
+// O(1) average-case operations for Add, Remove and Contains.
+type BoundedSet[T comparable] interface {
+	Set[T]
+	// Capacity returns the maximum number of elements.
+	Capacity() int
+}
+
+// NewBoundedSet creates a new thread-safe set holding at most capacity elements.
+// A capacity below 1 is treated as 1. If more initial elements than capacity are given,
+// only the last ones are kept.
+// Set operations like Union return an unbounded Set that keeps the order of the elements.
+//
+// Example:
+//
+//	set := collection.NewBoundedSet(2, "a", "b")
+//	set.Contains("a")
+//	set.Add("c") // evicts "b"
+func NewBoundedSet[T comparable](capacity int, elements ...T) BoundedSet[T] {
+	return newBoundedSet(capacity, elements...)
+}
+
+func newBoundedSet[T comparable](capacity int, elements ...T) *boundedSet[T] {
+	s := &boundedSet[T]{
+		capacity: max(capacity, 1),
+		index:    make(map[T]*linkedListNode[T]),
+	}
+	s.Add(elements...)
+	return s
+}
+
+// boundedSet keeps the least recently used element at the head of the list.
+type boundedSet[T comparable] struct {
+	id       atomic.Uint64
+	mux      sync.Mutex
+	capacity int
+	list     linkedList[T]
+	index    map[T]*linkedListNode[T]
+	notifier setNotifier[T]
+}
+
+func (s *boundedSet[T]) lockOrder() uint64 {
+	return setLockID(&s.id)
+}
+
+func (s *boundedSet[T]) lock() {
+	s.mux.Lock()
+}
+
+func (s *boundedSet[T]) unlock() {
+	s.mux.Unlock()
+}
+
+// releaseLocked unlocks the set and delivers the events to the subscribers.
+func (s *boundedSet[T]) releaseLocked(events []SetEvent[T]) {
+	s.notifier.release(&s.mux, events...)
+}
+
+func (s *boundedSet[T]) containsLocked(element T) bool {
+	_, found := s.index[element]
+	return found
+}
+
+func (s *boundedSet[T]) lengthLocked() int {
+	return s.list.length
+}
+
+func (s *boundedSet[T]) sliceLocked() []T {
+	return s.list.values()
+}
+
+// insert adds element or marks it as most recently used. It reports whether the element
+// was added and appends evicted elements to evicted.
+func (s *boundedSet[T]) insert(element T, evicted []T) (bool, []T) {
+	if node, found := s.index[element]; found {
+		s.list.moveToBack(node)
+		return false, evicted
+	}
+	if s.index == nil {
+		s.index = make(map[T]*linkedListNode[T])
+	}
+	s.index[element] = s.list.pushBack(element)
+	for s.list.length > s.capacity {
+		node := s.list.head
+		s.list.remove(node)
+		delete(s.index, node.value)
+		evicted = append(evicted, node.value)
+	}
+	return true, evicted
+}
+
+func (s *boundedSet[T]) Capacity() int {
+	return s.capacity
+}
+
+func (s *boundedSet[T]) Add(elements ...T) {
+	s.mux.Lock()
+	s.notifier.release(&s.mux, s.addLocked(elements)...)
+}
+
+// addLocked inserts elements and returns the events of added and evicted elements
+// for the subscribers.
+func (s *boundedSet[T]) addLocked(elements []T) []SetEvent[T] {
+	track := s.notifier.hasSubscribers()
+	var added, evicted []T
+	for _, element := range elements {
+		var ok bool
+		ok, evicted = s.insert(element, evicted)
+		if ok && track {
+			added = append(added, element)
+		}
+	}
+	if !track {
+		return nil
+	}
+	return []SetEvent[T]{
+		{Type: SetEventAdded, Elements: added},
+		{Type: SetEventRemoved, Elements: evicted},
+	}
+}
+
+func (s *boundedSet[T]) Remove(elements ...T) {
+	s.mux.Lock()
+	s.notifier.release(&s.mux, s.removeLocked(elements)...)
+}
+
+// removeLocked deletes elements and returns the event for the subscribers.
+func (s *boundedSet[T]) removeLocked(elements []T) []SetEvent[T] {
+	track := s.notifier.hasSubscribers()
+	var removed []T
+	for _, element := range elements {
+		node, found := s.index[element]
+		if !found {
+			continue
+		}
+		s.list.remove(node)
+		delete(s.index, element)
+		if track {
+			removed = append(removed, element)
+		}
+	}
+	return []SetEvent[T]{{Type: SetEventRemoved, Elements: removed}}
+}
+
+// replace swaps the content of the set for elements and reports the changes to subscribers.
+func (s *boundedSet[T]) replace(elements []T) {
+	s.mux.Lock()
+	old := s.index
+	s.list = linkedList[T]{}
+	s.index = make(map[T]*linkedListNode[T])
+	for _, element := range elements {
+		_, _ = s.insert(element, nil)
+	}
+	if !s.notifier.hasSubscribers() {
+		s.mux.Unlock()
+		return
+	}
+	var added, removed []T
+	for element := range old {
+		if _, found := s.index[element]; !found {
+			removed = append(removed, element)
+		}
+	}
+	for node := s.list.head; node != nil; node = node.next {
+		if _, found := old[node.value]; !found {
+			added = append(added, node.value)
+		}
+	}
+	s.notifier.release(
+		&s.mux,
+		SetEvent[T]{Type: SetEventRemoved, Elements: removed},
+		SetEvent[T]{Type: SetEventAdded, Elements: added},
+	)
+}
+
+func (s *boundedSet[T]) Update(fn func(tx SetTx[T]) error) error {
+	return updateSet(s, sameElement[T], nil, fn)
+}
+
+func (s *boundedSet[T]) AddIfAbsent(element T) bool {
+	return addIfAbsent(s.Update, element)
+}
+
+func (s *boundedSet[T]) RemoveIfPresent(element T) bool {
+	return removeIfPresent(s.Update, element)
+}
+
+func (s *boundedSet[T]) Replace(oldElement T, newElement T) bool {
+	return replaceElement(s.Update, oldElement, newElement)
+}
+
+func (s *boundedSet[T]) CompareAndSwap(expected []T, elements []T) bool {
+	return compareAndSwap(s.Update, expected, elements)
+}
+
+// Subscribe returns a channel that receives an event for every change of the set.
+// Only elements that were actually added, removed or evicted are reported.
+// The channel is closed once ctx is canceled.
+func (s *boundedSet[T]) Subscribe(
+	ctx context.Context,
+	options SubscribeOptions,
+) <-chan SetEvent[T] {
+	return s.notifier.subscribe(ctx, options)
+}
+
+// Contains reports whether element is present and marks it as most recently used.
+func (s *boundedSet[T]) Contains(element T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	node, found := s.index[element]
+	if found {
+		s.list.moveToBack(node)
+	}
+	return found
+}
+
+func (s *boundedSet[T]) ContainsAll(elements ...T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, element := range elements {
+		if !s.containsLocked(element) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *boundedSet[T]) ContainsAny(elements ...T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, element := range elements {
+		if s.containsLocked(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// Slice returns all elements from the least to the most recently used.
+func (s *boundedSet[T]) Slice() []T {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.list.values()
+}
+
+func (s *boundedSet[T]) Length() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.list.length
+}
+
+// Strings returns all elements as their string representations in sorted order.
+// This provides deterministic output suitable for debugging and logging.
+func (s *boundedSet[T]) Strings() []string {
+	result := s.orderedStrings()
+	sort.Strings(result)
+	return result
+}
+
+// orderedStrings returns all elements as their string representations
+// from the least to the most recently used.
+func (s *boundedSet[T]) orderedStrings() []string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	result := make([]string, 0, s.list.length)
+	for node := s.list.head; node != nil; node = node.next {
+		result = append(result, elementToString(node.value))
+	}
+	return result
+}
+
+// String returns a human-readable string representation of the set.
+// Format: "BoundedSet[element1, element2, ...]" from the least to the most recently used.
+func (s *boundedSet[T]) String() string {
+	return formatSetString("BoundedSet[", s.orderedStrings())
+}
+
+// Each calls fn for each element of a snapshot of the set. Iteration stops on first error.
+// fn may access and change the set; changes are not reflected in the iteration.
+// Elements are iterated from the least to the most recently used without changing the recency.
+func (s *boundedSet[T]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return Each(ctx, s.Slice(), fn)
+}
+
+// EachLocked calls fn for each element while holding the lock of the set, so the set
+// can't change during the iteration. fn must not call methods of the set, which would
+// deadlock, and blocks all other callers while it runs.
+// Elements are iterated from the least to the most recently used without changing the recency.
+func (s *boundedSet[T]) EachLocked(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for node := s.list.head; node != nil; node = node.next {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := fn(ctx, node.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// EachParallel calls fn for each element of a snapshot of the set using up to workers
+// goroutines. The first error cancels the remaining calls and is returned.
+func (s *boundedSet[T]) EachParallel(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, false, fn)
+}
+
+// EachParallelCollectErrors calls fn for each element of a snapshot of the set using up to
+// workers goroutines and returns the errors of all failed calls joined.
+func (s *boundedSet[T]) EachParallelCollectErrors(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, true, fn)
+}
+
+// All returns an iterator over a snapshot of the elements from the least to the most recently used.
+// Changes to the set during iteration are not reflected.
+func (s *boundedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range s.Slice() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a new BoundedSet with the same capacity, elements and recency.
+// The returned set is a shallow copy - modifications to it won't affect the original.
+func (s *boundedSet[T]) Clone() Set[T] {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return newBoundedSet(s.capacity, s.list.values()...)
+}
+
+// Without returns a new BoundedSet containing all elements from the current set
+// except those specified in the elements parameter.
+// The original set is not modified.
+func (s *boundedSet[T]) Without(elements ...T) Set[T] {
+	result := s.Clone()
+	result.Remove(elements...)
+	return result
+}
+
+// Union returns a new unbounded Set containing all elements of the current set followed by
+// the elements of other.
+func (s *boundedSet[T]) Union(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return newLinkedSet(unionLocked[T](sets)...)
+}
+
+// Intersection returns a new unbounded Set containing the elements present in both
+// the current set and other.
+func (s *boundedSet[T]) Intersection(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return newLinkedSet(intersectionLocked[T](sets)...)
+}
+
+// Difference returns a new unbounded Set containing the elements of the current set
+// not present in other.
+func (s *boundedSet[T]) Difference(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return newLinkedSet(differenceLocked[T](sets)...)
+}
+
+// SymmetricDifference returns a new unbounded Set containing the elements present in
+// exactly one of the current set and other.
+func (s *boundedSet[T]) SymmetricDifference(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return newLinkedSet(symmetricDifferenceLocked[T](sets)...)
+}
+
+// IsSubsetOf reports whether all elements of the current set are present in other.
+func (s *boundedSet[T]) IsSubsetOf(other Set[T]) bool {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isSubsetLocked[T](sets[0], sets[1])
+}
+
+// IsSupersetOf reports whether all elements of other are present in the current set.
+func (s *boundedSet[T]) IsSupersetOf(other Set[T]) bool {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isSubsetLocked[T](sets[1], sets[0])
+}
+
+// IsDisjoint reports whether the current set and other have no elements in common.
+func (s *boundedSet[T]) IsDisjoint(other Set[T]) bool {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isDisjointLocked[T](sets[0], sets[1])
+}
+
+// MarshalText implements encoding.TextMarshaler for BoundedSet.
+// Elements are written from the least to the most recently used.
+func (s *boundedSet[T]) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(s.orderedStrings(), ",")), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for BoundedSet.
+// If text contains more elements than the capacity, only the last ones are kept.
+func (s *boundedSet[T]) UnmarshalText(text []byte) error {
+	parts := splitText(string(text))
+	elements := make([]T, 0, len(parts))
+	for _, part := range parts {
+		element, err := parseTextElement[T](part)
+		if err != nil {
+			return err
+		}
+		elements = append(elements, element)
+	}
+
+	s.replace(elements)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for BoundedSet.
+// It serializes the set as a JSON array from the least to the most recently used element,
+// so unmarshalling restores the recency.
+func (s *boundedSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Slice())
+}
+
+// UnmarshalJSON implements json.Unmarshaler for BoundedSet.
+// If the array contains more elements than the capacity, only the last ones are kept.
+func (s *boundedSet[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	s.replace(elements)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler for BoundedSet.
+// Elements are written from the least to the most recently used.
+func (s *boundedSet[T]) MarshalBinary() ([]byte, error) {
+	return marshalSetBinary(s.Slice())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for BoundedSet.
+// The set is left unchanged if data is invalid.
+func (s *boundedSet[T]) UnmarshalBinary(data []byte) error {
+	elements, err := unmarshalSetBinary[T](data)
+	if err != nil {
+		return err
+	}
+	s.replace(elements)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder for BoundedSet using the binary format.
+func (s *boundedSet[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder for BoundedSet using the binary format.
+func (s *boundedSet[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
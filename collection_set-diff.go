@@ -0,0 +1,184 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// SetDiff describes the changes between two snapshots of a Set.
+// It can be encoded as JSON to send changes and replayed with Apply.
+// The elements are sorted like in Set.MarshalJSON, so equal diffs encode to the same JSON.
+type SetDiff[T comparable] struct {
+	// Added contains the elements only present in the newer set.
+	Added []T `json:"added,omitempty"`
+	// Removed contains the elements only present in the older set.
+	Removed []T `json:"removed,omitempty"`
+}
+
+// IsEmpty reports whether the diff contains no changes.
+func (d SetDiff[T]) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// SetHashCodeDiff describes the changes between two snapshots of a SetHashCode.
+// It can be encoded as JSON to send changes and replayed with ApplySetHashCode.
+// The elements are sorted by hash code, so equal diffs encode to the same JSON.
+type SetHashCodeDiff[T HasHashCode] struct {
+	// Added contains the elements whose hash code is only present in the newer set.
+	Added []T `json:"added,omitempty"`
+	// Removed contains the elements whose hash code is only present in the older set.
+	Removed []T `json:"removed,omitempty"`
+	// Changed contains the new value of elements whose hash code is present in both sets
+	// but whose value differs.
+	Changed []T `json:"changed,omitempty"`
+}
+
+// IsEmpty reports whether the diff contains no changes.
+func (d SetHashCodeDiff[T]) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// SetEqualDiff describes the changes between two snapshots of a SetEqual.
+// It can be encoded as JSON to send changes and replayed with ApplySetEqual.
+type SetEqualDiff[T HasEqual[T]] struct {
+	// Added contains the elements only present in the newer set.
+	Added []T `json:"added,omitempty"`
+	// Removed contains the elements only present in the older set.
+	Removed []T `json:"removed,omitempty"`
+}
+
+// IsEmpty reports whether the diff contains no changes.
+func (d SetEqualDiff[T]) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// Diff returns the changes required to turn from into to.
+// Both sets are read from a snapshot, so they may be modified concurrently.
+//
+// Example:
+//
+//	diff := collection.Diff(actual, desired)
+//	create(diff.Added)
+//	delete(diff.Removed)
+func Diff[T comparable](from Set[T], to Set[T]) SetDiff[T] {
+	oldElements := newSet[T](from.Slice()...)
+	newElements := newSet[T](to.Slice()...)
+
+	var result SetDiff[T]
+	for element := range newElements.data {
+		if _, found := oldElements.data[element]; !found {
+			result.Added = append(result.Added, element)
+		}
+	}
+	for element := range oldElements.data {
+		if _, found := newElements.data[element]; !found {
+			result.Removed = append(result.Removed, element)
+		}
+	}
+	sortElements(result.Added)
+	sortElements(result.Removed)
+	return result
+}
+
+// DiffSetHashCode returns the changes required to turn from into to.
+// Elements with the same hash code are reported as changed if their values differ.
+// Values are compared with the Equal method if T implements HasEqual, otherwise with
+// reflect.DeepEqual.
+func DiffSetHashCode[T HasHashCode](from SetHashCode[T], to SetHashCode[T]) SetHashCodeDiff[T] {
+	oldElements := newSetHashCode(from.Slice()...)
+	newElements := newSetHashCode(to.Slice()...)
+
+	var result SetHashCodeDiff[T]
+	for hashCode, element := range newElements.data {
+		oldElement, found := oldElements.data[hashCode]
+		switch {
+		case !found:
+			result.Added = append(result.Added, element)
+		case !valuesEqual(oldElement, element):
+			result.Changed = append(result.Changed, element)
+		}
+	}
+	for hashCode, element := range oldElements.data {
+		if _, found := newElements.data[hashCode]; !found {
+			result.Removed = append(result.Removed, element)
+		}
+	}
+	sortByHashCode(result.Added)
+	sortByHashCode(result.Removed)
+	sortByHashCode(result.Changed)
+	return result
+}
+
+// sortByHashCode sorts elements in place by their hash code.
+func sortByHashCode[T HasHashCode](elements []T) {
+	slices.SortFunc(elements, func(a, b T) int {
+		return strings.Compare(a.HashCode(), b.HashCode())
+	})
+}
+
+// DiffSetEqual returns the changes required to turn from into to.
+// Added and Removed keep the insertion order of to and from.
+func DiffSetEqual[T HasEqual[T]](from SetEqual[T], to SetEqual[T]) SetEqualDiff[T] {
+	oldElements := newSetEqual(from.Slice()...)
+	newElements := newSetEqual(to.Slice()...)
+
+	var result SetEqualDiff[T]
+	for _, element := range newElements.list.values() {
+		if !oldElements.contains(element) {
+			result.Added = append(result.Added, element)
+		}
+	}
+	for _, element := range oldElements.list.values() {
+		if !newElements.contains(element) {
+			result.Removed = append(result.Removed, element)
+		}
+	}
+	return result
+}
+
+// Apply replays diff on set by removing diff.Removed and adding diff.Added in one Update.
+// Applying the same diff twice has no further effect.
+func Apply[T comparable](set Set[T], diff SetDiff[T]) {
+	_ = set.Update(func(tx SetTx[T]) error {
+		tx.Remove(diff.Removed...)
+		tx.Add(diff.Added...)
+		return nil
+	})
+}
+
+// ApplySetHashCode replays diff on set by removing diff.Removed, adding diff.Added
+// and replacing the elements in diff.Changed in one Update.
+// Applying the same diff twice has no further effect.
+func ApplySetHashCode[T HasHashCode](set SetHashCode[T], diff SetHashCodeDiff[T]) {
+	_ = set.Update(func(tx SetTx[T]) error {
+		tx.Remove(diff.Removed...)
+		tx.Add(diff.Added...)
+		tx.Add(diff.Changed...)
+		return nil
+	})
+}
+
+// ApplySetEqual replays diff on set by removing diff.Removed and adding diff.Added
+// in one Update.
+// Applying the same diff twice has no further effect.
+func ApplySetEqual[T HasEqual[T]](set SetEqual[T], diff SetEqualDiff[T]) {
+	_ = set.Update(func(tx SetTx[T]) error {
+		tx.Remove(diff.Removed...)
+		tx.Add(diff.Added...)
+		return nil
+	})
+}
+
+// valuesEqual compares two values with their Equal method if available, otherwise with
+// reflect.DeepEqual.
+func valuesEqual[T any](a T, b T) bool {
+	if equal, ok := any(a).(HasEqual[T]); ok {
+		return equal.Equal(b)
+	}
+	return reflect.DeepEqual(a, b)
+}
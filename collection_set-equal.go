@@ -7,8 +7,10 @@ package collection
 import (
 	"context"
 	"encoding/json"
+	"iter"
 	"sort"
 	"sync"
+	"sync/atomic"
 )
 
 // HasEqual represents types that can compare themselves for equality with another value.
@@ -16,12 +18,24 @@ type HasEqual[V any] interface {
 	Equal(value V) bool
 }
 
+// HasEqualHash is an optional hint for SetEqual elements.
+// Elements that are Equal MUST return the same EqualHash. Different elements may share
+// a hash; they are then told apart by their Equal method.
+// A cheap hash that spreads elements well reduces Add, Remove and Contains to
+// O(1) average-case operations.
+type HasEqualHash interface {
+	EqualHash() uint64
+}
+
+//counterfeiter:generate -o mocks/collection-set-equal.go --fake-name CollectionSetEqual . SetEqual
+
 // SetEqual represents a thread-safe set for types that implement HasEqual.
 // Elements are uniquely identified by their Equal method.
 //
-// Performance: This implementation uses a slice-based approach. Operations have
-// O(n) complexity where n is the number of elements. For large sets or performance-critical
-// code, consider using SetHashCode which provides O(1) average-case operations.
+// Performance: Elements are grouped into buckets by their EqualHash if they implement
+// HasEqualHash, and Equal is only called within a bucket. Without the hint all elements
+// share one bucket and operations have O(n) complexity where n is the number of elements.
+// For elements with a unique string identity, consider using SetHashCode instead.
 type SetEqual[T HasEqual[T]] interface {
 	// Add inserts elements into the set, using the Equal method for uniqueness checking.
 	// Duplicate elements are automatically ignored.
@@ -30,6 +44,20 @@ type SetEqual[T HasEqual[T]] interface {
 	// Remove deletes elements from the set using its Equal method for matching.
 	// Multiple elements can be removed in a single call with only one mutex lock.
 	Remove(elements ...T)
+	// AddIfAbsent inserts element if it is not present and reports whether it was added.
+	AddIfAbsent(element T) bool
+	// RemoveIfPresent deletes element if it is present and reports whether it was removed.
+	RemoveIfPresent(element T) bool
+	// Replace removes oldElement and inserts newElement in one step if oldElement is present.
+	// It reports whether oldElement was present; otherwise the set is not changed.
+	Replace(oldElement T, newElement T) bool
+	// CompareAndSwap replaces the elements of the set with elements if the set contains
+	// exactly the elements of expected, and reports whether they were replaced.
+	CompareAndSwap(expected []T, elements []T) bool
+	// Update calls fn with a transaction holding the lock of the set. The changes made through
+	// the transaction are applied together if fn returns nil and discarded otherwise.
+	// fn must not call methods of the set, which would deadlock.
+	Update(fn func(tx SetTx[T]) error) error
 	// Contains reports whether an element matching the given value is present in the set.
 	Contains(element T) bool
 	// ContainsAll reports whether all given elements are present in the set using the Equal method.
@@ -45,9 +73,32 @@ type SetEqual[T HasEqual[T]] interface {
 	Strings() []string
 	// String returns a human-readable string representation of the set.
 	String() string
-	// Each calls fn for each element in the set. Iteration stops on first error.
+	// Each calls fn for each element of a snapshot of the set. Iteration stops on first error.
+	// fn may access and change the set; changes are not reflected in the iteration.
 	// Elements are iterated in insertion order (FIFO).
 	Each(ctx context.Context, fn func(ctx context.Context, value T) error) error
+	// EachLocked calls fn for each element while holding the lock of the set, so the set
+	// can't change during the iteration. fn must not call methods of the set, which would
+	// deadlock, and blocks all other callers while it runs.
+	// Elements are iterated in insertion order (FIFO).
+	EachLocked(ctx context.Context, fn func(ctx context.Context, value T) error) error
+	// EachParallel calls fn for each element of a snapshot of the set using up to workers
+	// goroutines. The first error cancels the remaining calls and is returned.
+	EachParallel(
+		ctx context.Context,
+		workers int,
+		fn func(ctx context.Context, value T) error,
+	) error
+	// EachParallelCollectErrors calls fn for each element of a snapshot of the set using up to
+	// workers goroutines and returns the errors of all failed calls joined.
+	EachParallelCollectErrors(
+		ctx context.Context,
+		workers int,
+		fn func(ctx context.Context, value T) error,
+	) error
+	// All returns an iterator over a snapshot of the elements in insertion order (FIFO).
+	// Changes to the set during iteration are not reflected.
+	All() iter.Seq[T]
 	// Clone returns a new SetEqual containing all elements from the current set.
 	// The returned set is a shallow copy - modifications to it won't affect the original.
 	Clone() SetEqual[T]
@@ -61,15 +112,49 @@ type SetEqual[T HasEqual[T]] interface {
 	// MarshalJSON serializes set elements to a JSON array.
 	// It implements json.Marshaler for automatic JSON serialization.
 	MarshalJSON() ([]byte, error)
+	// MarshalBinary encodes set elements into a compact, versioned binary format.
+	// It implements encoding.BinaryMarshaler.
+	MarshalBinary() ([]byte, error)
+	// UnmarshalBinary replaces the set elements with the elements decoded from data.
+	// It implements encoding.BinaryUnmarshaler.
+	UnmarshalBinary(data []byte) error
+	// GobEncode implements gob.GobEncoder using the binary format.
+	GobEncode() ([]byte, error)
+	// GobDecode implements gob.GobDecoder using the binary format.
+	// gob decodes into the existing set, so struct fields of this type must be initialized
+	// before decoding, e.g. with collection.NewSetEqual[T]().
+	GobDecode(data []byte) error
+	// Union returns a new SetEqual containing all elements of the current set followed by
+	// the elements of other.
+	Union(other SetEqual[T]) SetEqual[T]
+	// Intersection returns a new SetEqual containing the elements present in both
+	// the current set and other, in the insertion order of the current set.
+	Intersection(other SetEqual[T]) SetEqual[T]
+	// Difference returns a new SetEqual containing the elements of the current set
+	// not present in other, in the insertion order of the current set.
+	Difference(other SetEqual[T]) SetEqual[T]
+	// SymmetricDifference returns a new SetEqual containing the elements present in exactly one of
+	// the current set and other.
+	SymmetricDifference(other SetEqual[T]) SetEqual[T]
+	// IsSubsetOf reports whether all elements of the current set are present in other.
+	IsSubsetOf(other SetEqual[T]) bool
+	// IsSupersetOf reports whether all elements of other are present in the current set.
+	IsSupersetOf(other SetEqual[T]) bool
+	// IsDisjoint reports whether the current set and other have no elements in common.
+	IsDisjoint(other SetEqual[T]) bool
+	// Subscribe returns a channel that receives an event for every change of the set.
+	// Only elements that were actually added or removed are reported.
+	// The channel is closed once ctx is canceled.
+	Subscribe(ctx context.Context, options SubscribeOptions) <-chan SetEvent[T]
 }
 
 // NewSetEqual creates a new thread-safe set for types that implement HasEqual.
 // It accepts optional initial elements to populate the set.
 // Duplicate elements are automatically handled using the Equal method.
 //
-// Performance: This implementation uses a slice-based approach with O(n) operations.
-// Initialization with k elements has O(k²) complexity due to uniqueness checks.
-// For better performance with large sets, use NewSetHashCode instead.
+// Performance: Elements implementing HasEqualHash get O(1) average-case operations.
+// Otherwise operations are O(n) and initialization with k elements has O(k²) complexity
+// due to uniqueness checks.
 //
 // Example:
 //
@@ -77,49 +162,209 @@ type SetEqual[T HasEqual[T]] interface {
 //	func (u User) Equal(other User) bool { return u.ID == other.ID }
 //	set := collection.NewSetEqual(User{1, "Alice"}, User{2, "Bob"})
 func NewSetEqual[T HasEqual[T]](elements ...T) SetEqual[T] {
-	s := &setEqual[T]{
-		data: make([]T, 0),
-	}
+	return newSetEqual(elements...)
+}
+
+func newSetEqual[T HasEqual[T]](elements ...T) *setEqual[T] {
+	s := &setEqual[T]{}
 	s.Add(elements...)
 	return s
 }
 
+// setEqual keeps its elements in a linked list to preserve insertion order
+// and indexes the list nodes by EqualHash.
 type setEqual[T HasEqual[T]] struct {
-	mux  sync.Mutex
-	data []T
+	id       atomic.Uint64
+	mux      sync.Mutex
+	list     linkedList[T]
+	buckets  map[uint64][]*linkedListNode[T]
+	notifier setNotifier[T]
+}
+
+// equalHash returns the bucket of element. Elements without HasEqualHash share bucket 0.
+func equalHash[T any](element T) uint64 {
+	if h, ok := any(element).(HasEqualHash); ok {
+		return h.EqualHash()
+	}
+	return 0
+}
+
+func (s *setEqual[T]) lockOrder() uint64 {
+	return setLockID(&s.id)
+}
+
+func (s *setEqual[T]) lock() {
+	s.mux.Lock()
+}
+
+func (s *setEqual[T]) unlock() {
+	s.mux.Unlock()
+}
+
+// releaseLocked unlocks the set and delivers the events to the subscribers.
+func (s *setEqual[T]) releaseLocked(events []SetEvent[T]) {
+	s.notifier.release(&s.mux, events...)
+}
+
+func (s *setEqual[T]) containsLocked(element T) bool {
+	return s.contains(element)
+}
+
+func (s *setEqual[T]) getLocked(element T) (T, bool) {
+	if node := s.find(element); node != nil {
+		return node.value, true
+	}
+	var zero T
+	return zero, false
+}
+
+func (s *setEqual[T]) lengthLocked() int {
+	return s.list.length
+}
+
+func (s *setEqual[T]) sliceLocked() []T {
+	return s.list.values()
+}
+
+// find returns the node holding an element equal to element, or nil.
+func (s *setEqual[T]) find(element T) *linkedListNode[T] {
+	for _, node := range s.buckets[equalHash(element)] {
+		if node.value.Equal(element) {
+			return node
+		}
+	}
+	return nil
+}
+
+// insert appends element if no equal element is present and reports whether it was added.
+func (s *setEqual[T]) insert(element T) bool {
+	if s.find(element) != nil {
+		return false
+	}
+	if s.buckets == nil {
+		s.buckets = make(map[uint64][]*linkedListNode[T])
+	}
+	hash := equalHash(element)
+	s.buckets[hash] = append(s.buckets[hash], s.list.pushBack(element))
+	return true
+}
+
+// delete removes the element equal to element and returns the removed element.
+func (s *setEqual[T]) delete(element T) (T, bool) {
+	hash := equalHash(element)
+	bucket := s.buckets[hash]
+	for i, node := range bucket {
+		if !node.value.Equal(element) {
+			continue
+		}
+		s.list.remove(node)
+		if len(bucket) == 1 {
+			delete(s.buckets, hash)
+		} else {
+			s.buckets[hash] = append(bucket[:i:i], bucket[i+1:]...)
+		}
+		return node.value, true
+	}
+	var zero T
+	return zero, false
+}
+
+// reset removes all elements.
+func (s *setEqual[T]) reset() {
+	s.list = linkedList[T]{}
+	s.buckets = nil
 }
 
 func (s *setEqual[T]) Add(elements ...T) {
 	s.mux.Lock()
-	defer s.mux.Unlock()
+	s.notifier.release(&s.mux, s.addLocked(elements)...)
+}
 
+// addLocked inserts elements and returns the event for the subscribers.
+func (s *setEqual[T]) addLocked(elements []T) []SetEvent[T] {
+	track := s.notifier.hasSubscribers()
+	var added []T
 	for _, element := range elements {
-		if s.contains(element) {
-			continue
+		if s.insert(element) && track {
+			added = append(added, element)
 		}
-		s.data = append(s.data, element)
 	}
+	return []SetEvent[T]{{Type: SetEventAdded, Elements: added}}
 }
 
 func (s *setEqual[T]) Remove(elements ...T) {
 	s.mux.Lock()
-	defer s.mux.Unlock()
+	s.notifier.release(&s.mux, s.removeLocked(elements)...)
+}
 
-	result := make([]T, 0, len(s.data))
-	for _, e := range s.data {
-		shouldRemove := false
-		for _, element := range elements {
-			if e.Equal(element) {
-				shouldRemove = true
-				break
-			}
+// removeLocked deletes elements and returns the event for the subscribers.
+func (s *setEqual[T]) removeLocked(elements []T) []SetEvent[T] {
+	track := s.notifier.hasSubscribers()
+	var removed []T
+	for _, element := range elements {
+		if existing, found := s.delete(element); found && track {
+			removed = append(removed, existing)
 		}
-		if shouldRemove {
-			continue
+	}
+	return []SetEvent[T]{{Type: SetEventRemoved, Elements: removed}}
+}
+
+// replace swaps the content of the set for elements and reports the changes to subscribers.
+func (s *setEqual[T]) replace(elements []T) {
+	s.mux.Lock()
+	old := s.list.values()
+	s.reset()
+	for _, element := range elements {
+		s.insert(element)
+	}
+	if !s.notifier.hasSubscribers() {
+		s.mux.Unlock()
+		return
+	}
+	var added, removed []T
+	oldSet := newSetEqual(old...)
+	for _, element := range old {
+		if !s.contains(element) {
+			removed = append(removed, element)
+		}
+	}
+	for node := s.list.head; node != nil; node = node.next {
+		if !oldSet.contains(node.value) {
+			added = append(added, node.value)
 		}
-		result = append(result, e)
 	}
-	s.data = result
+	s.notifier.release(
+		&s.mux,
+		SetEvent[T]{Type: SetEventRemoved, Elements: removed},
+		SetEvent[T]{Type: SetEventAdded, Elements: added},
+	)
+}
+
+func (s *setEqual[T]) Update(fn func(tx SetTx[T]) error) error {
+	return updateSet(s, equalHash[T], T.Equal, fn)
+}
+
+func (s *setEqual[T]) AddIfAbsent(element T) bool {
+	return addIfAbsent(s.Update, element)
+}
+
+func (s *setEqual[T]) RemoveIfPresent(element T) bool {
+	return removeIfPresent(s.Update, element)
+}
+
+func (s *setEqual[T]) Replace(oldElement T, newElement T) bool {
+	return replaceElement(s.Update, oldElement, newElement)
+}
+
+func (s *setEqual[T]) CompareAndSwap(expected []T, elements []T) bool {
+	return compareAndSwap(s.Update, expected, elements)
+}
+
+// Subscribe returns a channel that receives an event for every change of the set.
+// Only elements that were actually added or removed are reported.
+// The channel is closed once ctx is canceled.
+func (s *setEqual[T]) Subscribe(ctx context.Context, options SubscribeOptions) <-chan SetEvent[T] {
+	return s.notifier.subscribe(ctx, options)
 }
 
 func (s *setEqual[T]) Contains(element T) bool {
@@ -153,25 +398,20 @@ func (s *setEqual[T]) ContainsAny(elements ...T) bool {
 }
 
 func (s *setEqual[T]) contains(element T) bool {
-	for _, e := range s.data {
-		if e.Equal(element) {
-			return true
-		}
-	}
-	return false
+	return s.find(element) != nil
 }
 
 func (s *setEqual[T]) Slice() []T {
 	s.mux.Lock()
 	defer s.mux.Unlock()
-	return Copy(s.data)
+	return s.list.values()
 }
 
 func (s *setEqual[T]) Length() int {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 
-	return len(s.data)
+	return s.list.length
 }
 
 // Strings returns all elements as their string representations in sorted order.
@@ -180,9 +420,9 @@ func (s *setEqual[T]) Strings() []string {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 
-	result := make([]string, 0, len(s.data))
-	for _, e := range s.data {
-		result = append(result, elementToString(e))
+	result := make([]string, 0, s.list.length)
+	for node := s.list.head; node != nil; node = node.next {
+		result = append(result, elementToString(node.value))
 	}
 
 	sort.Strings(result)
@@ -196,18 +436,33 @@ func (s *setEqual[T]) String() string {
 	return formatSetString("SetEqual[", s.Strings())
 }
 
-// Each calls fn for each element in the set. Iteration stops on first error.
+// Each calls fn for each element of a snapshot of the set. Iteration stops on first error.
+// fn may access and change the set; changes are not reflected in the iteration.
 // Elements are iterated in insertion order (FIFO).
-func (s *setEqual[T]) Each(ctx context.Context, fn func(ctx context.Context, value T) error) error {
+func (s *setEqual[T]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return Each(ctx, s.Slice(), fn)
+}
+
+// EachLocked calls fn for each element while holding the lock of the set, so the set
+// can't change during the iteration. fn must not call methods of the set, which would
+// deadlock, and blocks all other callers while it runs.
+// Elements are iterated in insertion order (FIFO).
+func (s *setEqual[T]) EachLocked(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 
-	for _, element := range s.data {
+	for node := s.list.head; node != nil; node = node.next {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			if err := fn(ctx, element); err != nil {
+			if err := fn(ctx, node.value); err != nil {
 				return err
 			}
 		}
@@ -222,9 +477,12 @@ func (s *setEqual[T]) Clone() SetEqual[T] {
 	defer s.mux.Unlock()
 
 	result := &setEqual[T]{
-		data: make([]T, len(s.data)),
+		buckets: make(map[uint64][]*linkedListNode[T], len(s.buckets)),
+	}
+	for node := s.list.head; node != nil; node = node.next {
+		hash := equalHash(node.value)
+		result.buckets[hash] = append(result.buckets[hash], result.list.pushBack(node.value))
 	}
-	copy(result.data, s.data)
 
 	return result
 }
@@ -238,13 +496,90 @@ func (s *setEqual[T]) Without(elements ...T) SetEqual[T] {
 	return result
 }
 
+// EachParallel calls fn for each element of a snapshot of the set using up to workers
+// goroutines. The first error cancels the remaining calls and is returned.
+func (s *setEqual[T]) EachParallel(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, false, fn)
+}
+
+// EachParallelCollectErrors calls fn for each element of a snapshot of the set using up to
+// workers goroutines and returns the errors of all failed calls joined.
+func (s *setEqual[T]) EachParallelCollectErrors(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, true, fn)
+}
+
+// All returns an iterator over a snapshot of the elements in insertion order (FIFO).
+// Changes to the set during iteration are not reflected.
+func (s *setEqual[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range s.Slice() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Union returns a new SetEqual containing all elements of the current set followed by
+// the elements of other.
+func (s *setEqual[T]) Union(other SetEqual[T]) SetEqual[T] {
+	return SetEqualUnion[T](s, other)
+}
+
+// Intersection returns a new SetEqual containing the elements present in both
+// the current set and other, in the insertion order of the current set.
+func (s *setEqual[T]) Intersection(other SetEqual[T]) SetEqual[T] {
+	return SetEqualIntersection[T](s, other)
+}
+
+// Difference returns a new SetEqual containing the elements of the current set
+// not present in other, in the insertion order of the current set.
+func (s *setEqual[T]) Difference(other SetEqual[T]) SetEqual[T] {
+	return SetEqualDifference[T](s, other)
+}
+
+// SymmetricDifference returns a new SetEqual containing the elements present in exactly one of
+// the current set and other.
+func (s *setEqual[T]) SymmetricDifference(other SetEqual[T]) SetEqual[T] {
+	return SetEqualSymmetricDifference[T](s, other)
+}
+
+// IsSubsetOf reports whether all elements of the current set are present in other.
+func (s *setEqual[T]) IsSubsetOf(other SetEqual[T]) bool {
+	sets := toSetEquals([]SetEqual[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isSubsetLocked[T](sets[0], sets[1])
+}
+
+// IsSupersetOf reports whether all elements of other are present in the current set.
+func (s *setEqual[T]) IsSupersetOf(other SetEqual[T]) bool {
+	sets := toSetEquals([]SetEqual[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isSubsetLocked[T](sets[1], sets[0])
+}
+
+// IsDisjoint reports whether the current set and other have no elements in common.
+func (s *setEqual[T]) IsDisjoint(other SetEqual[T]) bool {
+	sets := toSetEquals([]SetEqual[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isDisjointLocked[T](sets[0], sets[1])
+}
+
 // MarshalJSON implements json.Marshaler for SetEqual.
 // It serializes the set as a JSON array of elements, preserving insertion order.
 func (s *setEqual[T]) MarshalJSON() ([]byte, error) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 
-	return json.Marshal(s.data)
+	return json.Marshal(s.list.values())
 }
 
 // UnmarshalJSON implements json.Unmarshaler for SetEqual.
@@ -255,15 +590,33 @@ func (s *setEqual[T]) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	s.mux.Lock()
-	defer s.mux.Unlock()
-	s.data = make([]T, 0, len(elements))
+	s.replace(elements)
+	return nil
+}
 
-	for _, element := range elements {
-		if !s.contains(element) {
-			s.data = append(s.data, element)
-		}
-	}
+// MarshalBinary implements encoding.BinaryMarshaler for SetEqual.
+// Elements are written in insertion order.
+func (s *setEqual[T]) MarshalBinary() ([]byte, error) {
+	return marshalSetBinary(s.Slice())
+}
 
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for SetEqual.
+// The set is left unchanged if data is invalid.
+func (s *setEqual[T]) UnmarshalBinary(data []byte) error {
+	elements, err := unmarshalSetBinary[T](data)
+	if err != nil {
+		return err
+	}
+	s.replace(elements)
 	return nil
 }
+
+// GobEncode implements gob.GobEncoder for SetEqual using the binary format.
+func (s *setEqual[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder for SetEqual using the binary format.
+func (s *setEqual[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
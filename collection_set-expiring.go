@@ -0,0 +1,650 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"container/heap"
+	"context"
+	"encoding/json"
+	"iter"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ExpiringSet represents a thread-safe set whose elements expire after a time to live (TTL).
+// Expired elements vanish from Contains, Length, Each and all other methods. They are
+// removed lazily by the next operation on the set, by Cleanup or periodically by Run.
+// Subscribers receive a SetEventRemoved event for expired elements once they are removed.
+//
+// Performance: This implementation uses a map for O(1) lookups and a min-heap of expiry
+// times, so removing expired elements costs O(log n) per element.
+type ExpiringSet[T comparable] interface {
+	Set[T]
+	// AddWithTTL inserts elements that expire after ttl. Elements with a ttl <= 0 never expire.
+	// Adding an element that is already present resets its expiry.
+	AddWithTTL(ttl time.Duration, elements ...T)
+	// ExpiresAt returns the expiry of element. It returns the zero time for elements that
+	// never expire and false if the element is not present.
+	ExpiresAt(element T) (time.Time, bool)
+	// Cleanup removes all expired elements.
+	Cleanup()
+	// Run calls Cleanup every interval until ctx is canceled. It returns nil once ctx is canceled.
+	Run(ctx context.Context, interval time.Duration) error
+}
+
+// ExpiringSetOptions configure an ExpiringSet.
+type ExpiringSetOptions struct {
+	// DefaultTTL is the time to live of elements added with Add.
+	// With a DefaultTTL <= 0 elements added with Add never expire.
+	DefaultTTL time.Duration
+	// Now returns the current time. It defaults to time.Now and can be replaced
+	// to test expiry without sleeping.
+	Now func() time.Time
+}
+
+// NewExpiringSet creates a new thread-safe set whose elements expire after a TTL.
+// The given elements are added with options.DefaultTTL.
+//
+// Example:
+//
+//	seen := collection.NewExpiringSet[string](collection.ExpiringSetOptions{
+//		DefaultTTL: time.Minute,
+//	})
+//	seen.Add(id)
+//	seen.Contains(id) // true for one minute
+func NewExpiringSet[T comparable](options ExpiringSetOptions, elements ...T) ExpiringSet[T] {
+	return newExpiringSet(options.DefaultTTL, options.Now, elements...)
+}
+
+func newExpiringSet[T comparable](
+	defaultTTL time.Duration,
+	now func() time.Time,
+	elements ...T,
+) *expiringSet[T] {
+	if now == nil {
+		now = time.Now
+	}
+	s := &expiringSet[T]{
+		data:       make(map[T]time.Time),
+		defaultTTL: defaultTTL,
+		now:        now,
+	}
+	s.Add(elements...)
+	return s
+}
+
+type expiringSet[T comparable] struct {
+	id         atomic.Uint64
+	mux        sync.Mutex
+	data       map[T]time.Time
+	queue      expiringQueue[T]
+	notifier   setNotifier[T]
+	defaultTTL time.Duration
+	now        func() time.Time
+}
+
+func (s *expiringSet[T]) lockOrder() uint64 {
+	return setLockID(&s.id)
+}
+
+func (s *expiringSet[T]) lock() {
+	s.mux.Lock()
+}
+
+func (s *expiringSet[T]) unlock() {
+	s.mux.Unlock()
+}
+
+// releaseLocked removes expired elements, unlocks the set and delivers the events.
+func (s *expiringSet[T]) releaseLocked(events []SetEvent[T]) {
+	s.release(s.purgeLocked(s.now()), events...)
+}
+
+func (s *expiringSet[T]) containsLocked(element T) bool {
+	expiry, found := s.data[element]
+	return found && !isExpired(expiry, s.now())
+}
+
+func (s *expiringSet[T]) lengthLocked() int {
+	now := s.now()
+	result := 0
+	for _, expiry := range s.data {
+		if !isExpired(expiry, now) {
+			result++
+		}
+	}
+	return result
+}
+
+func (s *expiringSet[T]) sliceLocked() []T {
+	now := s.now()
+	result := make([]T, 0, len(s.data))
+	for element, expiry := range s.data {
+		if !isExpired(expiry, now) {
+			result = append(result, element)
+		}
+	}
+	return result
+}
+
+// acquire locks the set and removes all expired elements.
+// The returned elements must be passed to release.
+func (s *expiringSet[T]) acquire() []T {
+	s.mux.Lock()
+	return s.purgeLocked(s.now())
+}
+
+// release unlocks the set and reports expired elements and events to subscribers.
+func (s *expiringSet[T]) release(expired []T, events ...SetEvent[T]) {
+	s.notifier.release(
+		&s.mux,
+		append([]SetEvent[T]{{Type: SetEventRemoved, Elements: expired}}, events...)...,
+	)
+}
+
+// purgeLocked removes all elements expired at now and returns them.
+func (s *expiringSet[T]) purgeLocked(now time.Time) []T {
+	var expired []T
+	for len(s.queue) > 0 && isExpired(s.queue[0].expiry, now) {
+		entry := heap.Pop(&s.queue).(expiringEntry[T])
+		// skip entries of removed elements or elements added again with a new expiry
+		if expiry, found := s.data[entry.element]; found && expiry.Equal(entry.expiry) {
+			delete(s.data, entry.element)
+			expired = append(expired, entry.element)
+		}
+	}
+	return expired
+}
+
+// insertLocked adds element or resets its expiry and reports whether it was added.
+func (s *expiringSet[T]) insertLocked(element T, expiry time.Time) bool {
+	_, found := s.data[element]
+	if s.data == nil {
+		s.data = make(map[T]time.Time)
+	}
+	s.data[element] = expiry
+	if !expiry.IsZero() {
+		heap.Push(&s.queue, expiringEntry[T]{element: element, expiry: expiry})
+	}
+	s.compactLocked()
+	return !found
+}
+
+// compactLocked rebuilds the queue if it is dominated by stale entries.
+func (s *expiringSet[T]) compactLocked() {
+	if len(s.queue) <= 2*len(s.data)+16 {
+		return
+	}
+	queue := make(expiringQueue[T], 0, len(s.data))
+	for element, expiry := range s.data {
+		if !expiry.IsZero() {
+			queue = append(queue, expiringEntry[T]{element: element, expiry: expiry})
+		}
+	}
+	heap.Init(&queue)
+	s.queue = queue
+}
+
+func (s *expiringSet[T]) expiry(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return s.now().Add(ttl)
+}
+
+func (s *expiringSet[T]) Add(elements ...T) {
+	s.AddWithTTL(s.defaultTTL, elements...)
+}
+
+// AddWithTTL inserts elements that expire after ttl. Elements with a ttl <= 0 never expire.
+// Adding an element that is already present resets its expiry.
+func (s *expiringSet[T]) AddWithTTL(ttl time.Duration, elements ...T) {
+	expired := s.acquire()
+	s.release(expired, s.addWithTTLLocked(ttl, elements)...)
+}
+
+// addLocked inserts elements with the default TTL and returns the event for the subscribers.
+func (s *expiringSet[T]) addLocked(elements []T) []SetEvent[T] {
+	return s.addWithTTLLocked(s.defaultTTL, elements)
+}
+
+func (s *expiringSet[T]) addWithTTLLocked(ttl time.Duration, elements []T) []SetEvent[T] {
+	expiry := s.expiry(ttl)
+	track := s.notifier.hasSubscribers()
+	var added []T
+	for _, element := range elements {
+		if s.insertLocked(element, expiry) && track {
+			added = append(added, element)
+		}
+	}
+	return []SetEvent[T]{{Type: SetEventAdded, Elements: added}}
+}
+
+func (s *expiringSet[T]) Remove(elements ...T) {
+	expired := s.acquire()
+	s.release(expired, s.removeLocked(elements)...)
+}
+
+// removeLocked deletes elements and returns the event for the subscribers.
+func (s *expiringSet[T]) removeLocked(elements []T) []SetEvent[T] {
+	track := s.notifier.hasSubscribers()
+	var removed []T
+	for _, element := range elements {
+		if _, found := s.data[element]; !found {
+			continue
+		}
+		delete(s.data, element)
+		if track {
+			removed = append(removed, element)
+		}
+	}
+	return []SetEvent[T]{{Type: SetEventRemoved, Elements: removed}}
+}
+
+// replace swaps the content of the set for elements with the default TTL and reports
+// the changes to subscribers.
+func (s *expiringSet[T]) replace(elements []T) {
+	expired := s.acquire()
+	old := s.data
+	expiry := s.expiry(s.defaultTTL)
+	s.data = make(map[T]time.Time, len(elements))
+	s.queue = nil
+	for _, element := range elements {
+		s.insertLocked(element, expiry)
+	}
+	var added, removed []T
+	if s.notifier.hasSubscribers() {
+		for element := range old {
+			if _, found := s.data[element]; !found {
+				removed = append(removed, element)
+			}
+		}
+		for element := range s.data {
+			if _, found := old[element]; !found {
+				added = append(added, element)
+			}
+		}
+	}
+	s.release(
+		expired,
+		SetEvent[T]{Type: SetEventRemoved, Elements: removed},
+		SetEvent[T]{Type: SetEventAdded, Elements: added},
+	)
+}
+
+// ExpiresAt returns the expiry of element. It returns the zero time for elements that
+// never expire and false if the element is not present.
+func (s *expiringSet[T]) ExpiresAt(element T) (time.Time, bool) {
+	expired := s.acquire()
+	defer s.release(expired)
+
+	expiry, found := s.data[element]
+	return expiry, found
+}
+
+// Cleanup removes all expired elements.
+func (s *expiringSet[T]) Cleanup() {
+	s.release(s.acquire())
+}
+
+// Run calls Cleanup every interval until ctx is canceled. It returns nil once ctx is canceled.
+func (s *expiringSet[T]) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.Cleanup()
+		}
+	}
+}
+
+func (s *expiringSet[T]) Update(fn func(tx SetTx[T]) error) error {
+	return updateSet(s, sameElement[T], nil, fn)
+}
+
+func (s *expiringSet[T]) AddIfAbsent(element T) bool {
+	return addIfAbsent(s.Update, element)
+}
+
+func (s *expiringSet[T]) RemoveIfPresent(element T) bool {
+	return removeIfPresent(s.Update, element)
+}
+
+func (s *expiringSet[T]) Replace(oldElement T, newElement T) bool {
+	return replaceElement(s.Update, oldElement, newElement)
+}
+
+func (s *expiringSet[T]) CompareAndSwap(expected []T, elements []T) bool {
+	return compareAndSwap(s.Update, expected, elements)
+}
+
+// Subscribe returns a channel that receives an event for every change of the set.
+// Only elements that were actually added, removed or expired are reported.
+// The channel is closed once ctx is canceled.
+func (s *expiringSet[T]) Subscribe(
+	ctx context.Context,
+	options SubscribeOptions,
+) <-chan SetEvent[T] {
+	return s.notifier.subscribe(ctx, options)
+}
+
+func (s *expiringSet[T]) Contains(element T) bool {
+	expired := s.acquire()
+	defer s.release(expired)
+
+	_, found := s.data[element]
+	return found
+}
+
+func (s *expiringSet[T]) ContainsAll(elements ...T) bool {
+	expired := s.acquire()
+	defer s.release(expired)
+
+	for _, element := range elements {
+		if _, found := s.data[element]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *expiringSet[T]) ContainsAny(elements ...T) bool {
+	expired := s.acquire()
+	defer s.release(expired)
+
+	for _, element := range elements {
+		if _, found := s.data[element]; found {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *expiringSet[T]) Slice() []T {
+	expired := s.acquire()
+	defer s.release(expired)
+
+	result := make([]T, 0, len(s.data))
+	for element := range s.data {
+		result = append(result, element)
+	}
+	return result
+}
+
+func (s *expiringSet[T]) Length() int {
+	expired := s.acquire()
+	defer s.release(expired)
+
+	return len(s.data)
+}
+
+// Strings returns all elements as their string representations in sorted order.
+// This provides deterministic output suitable for debugging and logging.
+func (s *expiringSet[T]) Strings() []string {
+	elements := s.Slice()
+	result := make([]string, 0, len(elements))
+	for _, element := range elements {
+		result = append(result, elementToString(element))
+	}
+	sort.Strings(result)
+	return result
+}
+
+// String returns a human-readable string representation of the set.
+// Format: "ExpiringSet[element1, element2, ...]" for non-empty sets,
+// "ExpiringSet[]" for empty sets.
+func (s *expiringSet[T]) String() string {
+	return formatSetString("ExpiringSet[", s.Strings())
+}
+
+// Each calls fn for each element of a snapshot of the set. Iteration stops on first error.
+// fn may access and change the set; changes are not reflected in the iteration.
+// The order of iteration is arbitrary and not guaranteed to be consistent.
+func (s *expiringSet[T]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return Each(ctx, s.Slice(), fn)
+}
+
+// EachLocked calls fn for each element while holding the lock of the set, so the set
+// can't change during the iteration. fn must not call methods of the set, which would
+// deadlock, and blocks all other callers while it runs.
+// The order of iteration is arbitrary and not guaranteed to be consistent.
+func (s *expiringSet[T]) EachLocked(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	expired := s.acquire()
+	defer s.release(expired)
+
+	for element := range s.data {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := fn(ctx, element); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// EachParallel calls fn for each element of a snapshot of the set using up to workers
+// goroutines. The first error cancels the remaining calls and is returned.
+func (s *expiringSet[T]) EachParallel(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, false, fn)
+}
+
+// EachParallelCollectErrors calls fn for each element of a snapshot of the set using up to
+// workers goroutines and returns the errors of all failed calls joined.
+func (s *expiringSet[T]) EachParallelCollectErrors(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, true, fn)
+}
+
+// All returns an iterator over a snapshot of the elements in arbitrary order.
+// Changes to the set during iteration are not reflected.
+func (s *expiringSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range s.Slice() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a new ExpiringSet containing all elements from the current set
+// with their expiry, default TTL and clock.
+func (s *expiringSet[T]) Clone() Set[T] {
+	expired := s.acquire()
+	defer s.release(expired)
+
+	result := newExpiringSet[T](s.defaultTTL, s.now)
+	for element, expiry := range s.data {
+		result.insertLocked(element, expiry)
+	}
+	return result
+}
+
+// Without returns a new ExpiringSet containing all elements from the current set
+// except those specified in the elements parameter.
+// The original set is not modified.
+func (s *expiringSet[T]) Without(elements ...T) Set[T] {
+	result := s.Clone()
+	result.Remove(elements...)
+	return result
+}
+
+// Union returns a new Set containing all unexpired elements of the current set and other.
+func (s *expiringSet[T]) Union(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return newSet(unionLocked[T](sets)...)
+}
+
+// Intersection returns a new Set containing the unexpired elements present in both
+// the current set and other.
+func (s *expiringSet[T]) Intersection(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return newSet(intersectionLocked[T](sets)...)
+}
+
+// Difference returns a new Set containing the unexpired elements of the current set
+// not present in other.
+func (s *expiringSet[T]) Difference(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return newSet(differenceLocked[T](sets)...)
+}
+
+// SymmetricDifference returns a new Set containing the unexpired elements present in
+// exactly one of the current set and other.
+func (s *expiringSet[T]) SymmetricDifference(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return newSet(symmetricDifferenceLocked[T](sets)...)
+}
+
+// IsSubsetOf reports whether all unexpired elements of the current set are present in other.
+func (s *expiringSet[T]) IsSubsetOf(other Set[T]) bool {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isSubsetLocked[T](sets[0], sets[1])
+}
+
+// IsSupersetOf reports whether all elements of other are present and unexpired in the current set.
+func (s *expiringSet[T]) IsSupersetOf(other Set[T]) bool {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isSubsetLocked[T](sets[1], sets[0])
+}
+
+// IsDisjoint reports whether the current set and other have no unexpired elements in common.
+func (s *expiringSet[T]) IsDisjoint(other Set[T]) bool {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isDisjointLocked[T](sets[0], sets[1])
+}
+
+// MarshalText implements encoding.TextMarshaler for ExpiringSet.
+// Only the elements are written, their expiry is not part of the text.
+func (s *expiringSet[T]) MarshalText() ([]byte, error) {
+	return marshalSortedText(s.Slice(), nil), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for ExpiringSet.
+// The parsed elements replace the content of the set and expire after the default TTL.
+func (s *expiringSet[T]) UnmarshalText(text []byte) error {
+	parts := splitText(string(text))
+	elements := make([]T, 0, len(parts))
+	for _, part := range parts {
+		element, err := parseTextElement[T](part)
+		if err != nil {
+			return err
+		}
+		elements = append(elements, element)
+	}
+
+	s.replace(elements)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for ExpiringSet.
+// Only the elements are written, their expiry is not part of the JSON array.
+func (s *expiringSet[T]) MarshalJSON() ([]byte, error) {
+	return marshalSortedJSON(s.Slice(), nil)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for ExpiringSet.
+// The decoded elements replace the content of the set and expire after the default TTL.
+func (s *expiringSet[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	s.replace(elements)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler for ExpiringSet.
+// Only the elements are written, their expiry is not part of the binary format.
+func (s *expiringSet[T]) MarshalBinary() ([]byte, error) {
+	return marshalSetBinary(s.Slice())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for ExpiringSet.
+// The decoded elements replace the content of the set and expire after the default TTL.
+func (s *expiringSet[T]) UnmarshalBinary(data []byte) error {
+	elements, err := unmarshalSetBinary[T](data)
+	if err != nil {
+		return err
+	}
+	s.replace(elements)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder for ExpiringSet using the binary format.
+func (s *expiringSet[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder for ExpiringSet using the binary format.
+func (s *expiringSet[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// isExpired reports whether expiry is reached at now. The zero time never expires.
+func isExpired(expiry time.Time, now time.Time) bool {
+	return !expiry.IsZero() && !now.Before(expiry)
+}
+
+type expiringEntry[T any] struct {
+	element T
+	expiry  time.Time
+}
+
+// expiringQueue is a min-heap of expiry times implementing heap.Interface.
+type expiringQueue[T any] []expiringEntry[T]
+
+func (q expiringQueue[T]) Len() int {
+	return len(q)
+}
+
+func (q expiringQueue[T]) Less(i, j int) bool {
+	return q[i].expiry.Before(q[j].expiry)
+}
+
+func (q expiringQueue[T]) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+}
+
+func (q *expiringQueue[T]) Push(x any) {
+	*q = append(*q, x.(expiringEntry[T]))
+}
+
+func (q *expiringQueue[T]) Pop() any {
+	old := *q
+	n := len(old)
+	entry := old[n-1]
+	*q = old[:n-1]
+	return entry
+}
@@ -7,8 +7,11 @@ package collection
 import (
 	"context"
 	"encoding/json"
+	"iter"
+	"slices"
 	"sort"
 	"sync"
+	"sync/atomic"
 )
 
 // HasHashCode represents types that can provide a string hash code for themselves.
@@ -20,6 +23,8 @@ type HasHashCode interface {
 	HashCode() string
 }
 
+//counterfeiter:generate -o mocks/collection-set-hashcode.go --fake-name CollectionSetHashCode . SetHashCode
+
 // SetHashCode represents a thread-safe set for types that implement HasHashCode.
 // Elements are uniquely identified by their hash code.
 //
@@ -34,6 +39,20 @@ type SetHashCode[T HasHashCode] interface {
 	// Remove deletes elements from the set by their hash codes.
 	// Multiple elements can be removed in a single call with only one mutex lock.
 	Remove(elements ...T)
+	// AddIfAbsent inserts element if it is not present and reports whether it was added.
+	AddIfAbsent(element T) bool
+	// RemoveIfPresent deletes element if it is present and reports whether it was removed.
+	RemoveIfPresent(element T) bool
+	// Replace removes oldElement and inserts newElement in one step if oldElement is present.
+	// It reports whether oldElement was present; otherwise the set is not changed.
+	Replace(oldElement T, newElement T) bool
+	// CompareAndSwap replaces the elements of the set with elements if the set contains
+	// exactly the elements of expected, and reports whether they were replaced.
+	CompareAndSwap(expected []T, elements []T) bool
+	// Update calls fn with a transaction holding the lock of the set. The changes made through
+	// the transaction are applied together if fn returns nil and discarded otherwise.
+	// fn must not call methods of the set, which would deadlock.
+	Update(fn func(tx SetTx[T]) error) error
 	// Contains reports whether an element with the given hash code is present in the set.
 	Contains(element T) bool
 	// ContainsAll reports whether all given elements are present in the set by their hash codes.
@@ -49,9 +68,32 @@ type SetHashCode[T HasHashCode] interface {
 	Strings() []string
 	// String returns a human-readable string representation of the set.
 	String() string
-	// Each calls fn for each element in the set. Iteration stops on first error.
+	// Each calls fn for each element of a snapshot of the set. Iteration stops on first error.
+	// fn may access and change the set; changes are not reflected in the iteration.
 	// The order of iteration is arbitrary and not guaranteed to be consistent.
 	Each(ctx context.Context, fn func(ctx context.Context, value T) error) error
+	// EachLocked calls fn for each element while holding the lock of the set, so the set
+	// can't change during the iteration. fn must not call methods of the set, which would
+	// deadlock, and blocks all other callers while it runs.
+	// The order of iteration is arbitrary and not guaranteed to be consistent.
+	EachLocked(ctx context.Context, fn func(ctx context.Context, value T) error) error
+	// EachParallel calls fn for each element of a snapshot of the set using up to workers
+	// goroutines. The first error cancels the remaining calls and is returned.
+	EachParallel(
+		ctx context.Context,
+		workers int,
+		fn func(ctx context.Context, value T) error,
+	) error
+	// EachParallelCollectErrors calls fn for each element of a snapshot of the set using up to
+	// workers goroutines and returns the errors of all failed calls joined.
+	EachParallelCollectErrors(
+		ctx context.Context,
+		workers int,
+		fn func(ctx context.Context, value T) error,
+	) error
+	// All returns an iterator over a snapshot of the elements in arbitrary order.
+	// Changes to the set during iteration are not reflected.
+	All() iter.Seq[T]
 	// Clone returns a new SetHashCode containing all elements from the current set.
 	// The returned set is a shallow copy - modifications to it won't affect the original.
 	Clone() SetHashCode[T]
@@ -62,9 +104,42 @@ type SetHashCode[T HasHashCode] interface {
 	// UnmarshalJSON deserializes a JSON array into set elements.
 	// It implements json.Unmarshaler for automatic JSON parsing.
 	UnmarshalJSON(data []byte) error
-	// MarshalJSON serializes set elements to a JSON array.
+	// MarshalJSON serializes set elements to a JSON array ordered by hash code.
 	// It implements json.Marshaler for automatic JSON serialization.
 	MarshalJSON() ([]byte, error)
+	// MarshalBinary encodes set elements into a compact, versioned binary format.
+	// It implements encoding.BinaryMarshaler.
+	MarshalBinary() ([]byte, error)
+	// UnmarshalBinary replaces the set elements with the elements decoded from data.
+	// It implements encoding.BinaryUnmarshaler.
+	UnmarshalBinary(data []byte) error
+	// GobEncode implements gob.GobEncoder using the binary format.
+	GobEncode() ([]byte, error)
+	// GobDecode implements gob.GobDecoder using the binary format.
+	// gob decodes into the existing set, so struct fields of this type must be initialized
+	// before decoding, e.g. with collection.NewSetHashCode[T]().
+	GobDecode(data []byte) error
+	// Union returns a new SetHashCode containing all elements of the current set and other.
+	Union(other SetHashCode[T]) SetHashCode[T]
+	// Intersection returns a new SetHashCode containing the elements present in both
+	// the current set and other, compared by hash code.
+	Intersection(other SetHashCode[T]) SetHashCode[T]
+	// Difference returns a new SetHashCode containing the elements of the current set
+	// not present in other, compared by hash code.
+	Difference(other SetHashCode[T]) SetHashCode[T]
+	// SymmetricDifference returns a new SetHashCode containing the elements present in exactly one of
+	// the current set and other, compared by hash code.
+	SymmetricDifference(other SetHashCode[T]) SetHashCode[T]
+	// IsSubsetOf reports whether all elements of the current set are present in other.
+	IsSubsetOf(other SetHashCode[T]) bool
+	// IsSupersetOf reports whether all elements of other are present in the current set.
+	IsSupersetOf(other SetHashCode[T]) bool
+	// IsDisjoint reports whether the current set and other have no hash codes in common.
+	IsDisjoint(other SetHashCode[T]) bool
+	// Subscribe returns a channel that receives an event for every change of the set.
+	// Only elements whose hash code was actually added or removed are reported.
+	// The channel is closed once ctx is canceled.
+	Subscribe(ctx context.Context, options SubscribeOptions) <-chan SetEvent[T]
 }
 
 // NewSetHashCode creates a new thread-safe set for types that implement HasHashCode.
@@ -80,6 +155,24 @@ type SetHashCode[T HasHashCode] interface {
 //	func (u User) HashCode() string { return fmt.Sprintf("user-%d", u.ID) }
 //	set := collection.NewSetHashCode(User{1, "Alice"}, User{2, "Bob"})
 func NewSetHashCode[T HasHashCode](elements ...T) SetHashCode[T] {
+	return newSetHashCode(elements...)
+}
+
+// NewSetHashCodeWithCompare creates a new thread-safe set for types that implement HasHashCode
+// that uses compare to order elements in MarshalJSON. Without compare, elements are written
+// in the order of their hash codes. compare is kept by Clone, Without, Union, Intersection,
+// Difference and SymmetricDifference.
+func NewSetHashCodeWithCompare[T HasHashCode](
+	compare func(a, b T) int,
+	elements ...T,
+) SetHashCode[T] {
+	s := newSetHashCode[T]()
+	s.compare = compare
+	s.Add(elements...)
+	return s
+}
+
+func newSetHashCode[T HasHashCode](elements ...T) *setHashCode[T] {
 	s := &setHashCode[T]{
 		data: make(map[string]T),
 	}
@@ -88,26 +181,153 @@ func NewSetHashCode[T HasHashCode](elements ...T) SetHashCode[T] {
 }
 
 type setHashCode[T HasHashCode] struct {
-	mux  sync.Mutex
-	data map[string]T
+	id       atomic.Uint64
+	mux      sync.Mutex
+	data     map[string]T
+	notifier setNotifier[T]
+	compare  func(a, b T) int
+}
+
+func (s *setHashCode[T]) lockOrder() uint64 {
+	return setLockID(&s.id)
+}
+
+func (s *setHashCode[T]) lock() {
+	s.mux.Lock()
+}
+
+func (s *setHashCode[T]) unlock() {
+	s.mux.Unlock()
+}
+
+// releaseLocked unlocks the set and delivers the events to the subscribers.
+func (s *setHashCode[T]) releaseLocked(events []SetEvent[T]) {
+	s.notifier.release(&s.mux, events...)
+}
+
+func (s *setHashCode[T]) containsLocked(element T) bool {
+	_, found := s.data[element.HashCode()]
+	return found
+}
+
+func (s *setHashCode[T]) getLocked(element T) (T, bool) {
+	existing, found := s.data[element.HashCode()]
+	return existing, found
+}
+
+func (s *setHashCode[T]) lengthLocked() int {
+	return len(s.data)
+}
+
+func (s *setHashCode[T]) sliceLocked() []T {
+	result := make([]T, 0, len(s.data))
+	for _, v := range s.data {
+		result = append(result, v)
+	}
+	return result
 }
 
 func (s *setHashCode[T]) Add(elements ...T) {
 	s.mux.Lock()
-	defer s.mux.Unlock()
+	s.notifier.release(&s.mux, s.addLocked(elements)...)
+}
 
+// addLocked inserts elements and returns the event for the subscribers.
+func (s *setHashCode[T]) addLocked(elements []T) []SetEvent[T] {
+	track := s.notifier.hasSubscribers()
+	var added []T
 	for _, element := range elements {
-		s.data[element.HashCode()] = element
+		hashCode := element.HashCode()
+		_, found := s.data[hashCode]
+		s.data[hashCode] = element
+		if track && !found {
+			added = append(added, element)
+		}
 	}
+	return []SetEvent[T]{{Type: SetEventAdded, Elements: added}}
 }
 
 func (s *setHashCode[T]) Remove(elements ...T) {
 	s.mux.Lock()
-	defer s.mux.Unlock()
+	s.notifier.release(&s.mux, s.removeLocked(elements)...)
+}
 
+// removeLocked deletes elements and returns the event for the subscribers.
+func (s *setHashCode[T]) removeLocked(elements []T) []SetEvent[T] {
+	track := s.notifier.hasSubscribers()
+	var removed []T
+	for _, element := range elements {
+		hashCode := element.HashCode()
+		existing, found := s.data[hashCode]
+		if !found {
+			continue
+		}
+		delete(s.data, hashCode)
+		if track {
+			removed = append(removed, existing)
+		}
+	}
+	return []SetEvent[T]{{Type: SetEventRemoved, Elements: removed}}
+}
+
+// replace swaps the content of the set for elements and reports the changes to subscribers.
+func (s *setHashCode[T]) replace(elements []T) {
+	s.mux.Lock()
+	old := s.data
+	s.data = make(map[string]T, len(elements))
 	for _, element := range elements {
-		delete(s.data, element.HashCode())
+		s.data[element.HashCode()] = element
+	}
+	if !s.notifier.hasSubscribers() {
+		s.mux.Unlock()
+		return
+	}
+	var added, removed []T
+	for hashCode, element := range old {
+		if _, found := s.data[hashCode]; !found {
+			removed = append(removed, element)
+		}
 	}
+	for hashCode, element := range s.data {
+		if _, found := old[hashCode]; !found {
+			added = append(added, element)
+		}
+	}
+	s.notifier.release(
+		&s.mux,
+		SetEvent[T]{Type: SetEventRemoved, Elements: removed},
+		SetEvent[T]{Type: SetEventAdded, Elements: added},
+	)
+}
+
+func (s *setHashCode[T]) Update(fn func(tx SetTx[T]) error) error {
+	return updateSet(s, T.HashCode, sameHashCode[T], fn)
+}
+
+func (s *setHashCode[T]) AddIfAbsent(element T) bool {
+	return addIfAbsent(s.Update, element)
+}
+
+func (s *setHashCode[T]) RemoveIfPresent(element T) bool {
+	return removeIfPresent(s.Update, element)
+}
+
+func (s *setHashCode[T]) Replace(oldElement T, newElement T) bool {
+	return replaceElement(s.Update, oldElement, newElement)
+}
+
+func (s *setHashCode[T]) CompareAndSwap(expected []T, elements []T) bool {
+	return compareAndSwap(s.Update, expected, elements)
+}
+
+// Subscribe returns a channel that receives an event for every change of the set.
+// Only elements whose hash code was actually added or removed are reported.
+// The channel is closed once ctx is canceled.
+func (s *setHashCode[T]) Subscribe(
+	ctx context.Context,
+	options SubscribeOptions,
+) <-chan SetEvent[T] {
+	return s.notifier.subscribe(ctx, options)
 }
 
 func (s *setHashCode[T]) Contains(element T) bool {
@@ -182,11 +402,23 @@ func (s *setHashCode[T]) String() string {
 	return formatSetString("SetHashCode[", s.Strings())
 }
 
-// Each calls fn for each element in the set. Iteration stops on first error.
+// Each calls fn for each element of a snapshot of the set. Iteration stops on first error.
+// fn may access and change the set; changes are not reflected in the iteration.
 // The order of iteration is arbitrary and not guaranteed to be consistent.
 func (s *setHashCode[T]) Each(
 	ctx context.Context,
 	fn func(ctx context.Context, value T) error,
+) error {
+	return Each(ctx, s.Slice(), fn)
+}
+
+// EachLocked calls fn for each element while holding the lock of the set, so the set
+// can't change during the iteration. fn must not call methods of the set, which would
+// deadlock, and blocks all other callers while it runs.
+// The order of iteration is arbitrary and not guaranteed to be consistent.
+func (s *setHashCode[T]) EachLocked(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
 ) error {
 	s.mux.Lock()
 	defer s.mux.Unlock()
@@ -211,7 +443,8 @@ func (s *setHashCode[T]) Clone() SetHashCode[T] {
 	defer s.mux.Unlock()
 
 	result := &setHashCode[T]{
-		data: make(map[string]T, len(s.data)),
+		data:    make(map[string]T, len(s.data)),
+		compare: s.compare,
 	}
 
 	for k, v := range s.data {
@@ -230,15 +463,116 @@ func (s *setHashCode[T]) Without(elements ...T) SetHashCode[T] {
 	return result
 }
 
+// EachParallel calls fn for each element of a snapshot of the set using up to workers
+// goroutines. The first error cancels the remaining calls and is returned.
+func (s *setHashCode[T]) EachParallel(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, false, fn)
+}
+
+// EachParallelCollectErrors calls fn for each element of a snapshot of the set using up to
+// workers goroutines and returns the errors of all failed calls joined.
+func (s *setHashCode[T]) EachParallelCollectErrors(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, true, fn)
+}
+
+// All returns an iterator over a snapshot of the elements in arbitrary order.
+// Changes to the set during iteration are not reflected.
+func (s *setHashCode[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range s.Slice() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// derive returns a new set containing elements that keeps the compare of the current set.
+func (s *setHashCode[T]) derive(elements []T) *setHashCode[T] {
+	result := newSetHashCode(elements...)
+	result.compare = s.compare
+	return result
+}
+
+// Union returns a new SetHashCode containing all elements of the current set and other.
+// For elements with the same hash code, the element of other wins.
+func (s *setHashCode[T]) Union(other SetHashCode[T]) SetHashCode[T] {
+	sets := toSetHashCodes([]SetHashCode[T]{s, other})
+	defer lockOrdered(sets...)()
+	return s.derive(unionLocked[T](sets))
+}
+
+// Intersection returns a new SetHashCode containing the elements present in both
+// the current set and other, compared by hash code.
+func (s *setHashCode[T]) Intersection(other SetHashCode[T]) SetHashCode[T] {
+	sets := toSetHashCodes([]SetHashCode[T]{s, other})
+	defer lockOrdered(sets...)()
+	return s.derive(intersectionLocked[T](sets))
+}
+
+// Difference returns a new SetHashCode containing the elements of the current set
+// not present in other, compared by hash code.
+func (s *setHashCode[T]) Difference(other SetHashCode[T]) SetHashCode[T] {
+	sets := toSetHashCodes([]SetHashCode[T]{s, other})
+	defer lockOrdered(sets...)()
+	return s.derive(differenceLocked[T](sets))
+}
+
+// SymmetricDifference returns a new SetHashCode containing the elements present in exactly one of
+// the current set and other, compared by hash code.
+func (s *setHashCode[T]) SymmetricDifference(other SetHashCode[T]) SetHashCode[T] {
+	sets := toSetHashCodes([]SetHashCode[T]{s, other})
+	defer lockOrdered(sets...)()
+	return s.derive(symmetricDifferenceLocked[T](sets))
+}
+
+// IsSubsetOf reports whether all elements of the current set are present in other.
+func (s *setHashCode[T]) IsSubsetOf(other SetHashCode[T]) bool {
+	sets := toSetHashCodes([]SetHashCode[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isSubsetLocked[T](sets[0], sets[1])
+}
+
+// IsSupersetOf reports whether all elements of other are present in the current set.
+func (s *setHashCode[T]) IsSupersetOf(other SetHashCode[T]) bool {
+	sets := toSetHashCodes([]SetHashCode[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isSubsetLocked[T](sets[1], sets[0])
+}
+
+// IsDisjoint reports whether the current set and other have no hash codes in common.
+func (s *setHashCode[T]) IsDisjoint(other SetHashCode[T]) bool {
+	sets := toSetHashCodes([]SetHashCode[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isDisjointLocked[T](sets[0], sets[1])
+}
+
 // MarshalJSON implements json.Marshaler for SetHashCode.
-// It serializes the set as a JSON array of elements in arbitrary order.
+// It serializes the set as a JSON array of elements ordered by the compare function of
+// the set or by hash code, so equal sets always produce the same output.
 func (s *setHashCode[T]) MarshalJSON() ([]byte, error) {
 	s.mux.Lock()
-	defer s.mux.Unlock()
+	hashCodes := make([]string, 0, len(s.data))
+	for hashCode := range s.data {
+		hashCodes = append(hashCodes, hashCode)
+	}
+	sort.Strings(hashCodes)
+	elements := make([]T, 0, len(hashCodes))
+	for _, hashCode := range hashCodes {
+		elements = append(elements, s.data[hashCode])
+	}
+	s.mux.Unlock()
 
-	elements := make([]T, 0, len(s.data))
-	for _, v := range s.data {
-		elements = append(elements, v)
+	if s.compare != nil {
+		slices.SortStableFunc(elements, s.compare)
 	}
 	return json.Marshal(elements)
 }
@@ -251,13 +585,33 @@ func (s *setHashCode[T]) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	s.mux.Lock()
-	defer s.mux.Unlock()
-	s.data = make(map[string]T)
+	s.replace(elements)
+	return nil
+}
 
-	for _, element := range elements {
-		s.data[element.HashCode()] = element
-	}
+// MarshalBinary implements encoding.BinaryMarshaler for SetHashCode.
+// Elements are written in arbitrary order.
+func (s *setHashCode[T]) MarshalBinary() ([]byte, error) {
+	return marshalSetBinary(s.Slice())
+}
 
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for SetHashCode.
+// The set is left unchanged if data is invalid.
+func (s *setHashCode[T]) UnmarshalBinary(data []byte) error {
+	elements, err := unmarshalSetBinary[T](data)
+	if err != nil {
+		return err
+	}
+	s.replace(elements)
 	return nil
 }
+
+// GobEncode implements gob.GobEncoder for SetHashCode using the binary format.
+func (s *setHashCode[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder for SetHashCode using the binary format.
+func (s *setHashCode[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
@@ -0,0 +1,234 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"hash/maphash"
+	"iter"
+	"reflect"
+	"sort"
+)
+
+// ImmutableSetHashCode represents a persistent set for types that implement HasHashCode.
+// Elements are uniquely identified by their hash code.
+// Add and Remove never modify the set but return a new version that shares all unchanged
+// parts with the old one. An ImmutableSetHashCode is safe for concurrent use without locking.
+//
+// Performance: This implementation uses a hash array mapped trie (HAMT) with
+// O(log32 n) operations for Add, Remove and Contains. Equal skips all parts shared
+// between two versions, so comparing versions derived from each other is proportional
+// to their differences.
+type ImmutableSetHashCode[T HasHashCode] interface {
+	// Add returns a new version of the set containing elements.
+	// Elements with a hash code already present replace the present element, like Add of
+	// SetHashCode. The set itself is returned if no element was added or changed.
+	Add(elements ...T) ImmutableSetHashCode[T]
+	// Remove returns a new version of the set without the elements with the given hash codes.
+	// The set itself is returned if none of the elements is present.
+	Remove(elements ...T) ImmutableSetHashCode[T]
+	// Contains reports whether an element with the given hash code is present in the set.
+	Contains(element T) bool
+	// ContainsAll reports whether all given elements are present in the set.
+	ContainsAll(elements ...T) bool
+	// ContainsAny reports whether at least one of the given elements is present in the set.
+	ContainsAny(elements ...T) bool
+	// Slice returns all elements as a slice. The order is arbitrary.
+	Slice() []T
+	// Length returns the number of elements in the set.
+	Length() int
+	// Strings returns all elements as their string representations in sorted order.
+	Strings() []string
+	// String returns a human-readable string representation of the set.
+	String() string
+	// Each calls fn for each element in the set. Iteration stops on first error.
+	Each(ctx context.Context, fn func(ctx context.Context, value T) error) error
+	// All returns an iterator over the elements in arbitrary order.
+	All() iter.Seq[T]
+	// Equal reports whether the set and other contain the same hash codes.
+	Equal(other ImmutableSetHashCode[T]) bool
+	// ToSetHashCode returns a new mutable SetHashCode containing all elements of the set.
+	ToSetHashCode() SetHashCode[T]
+	// MarshalJSON serializes set elements to a JSON array ordered by hash code.
+	// It implements json.Marshaler for automatic JSON serialization.
+	MarshalJSON() ([]byte, error)
+}
+
+// NewImmutableSetHashCode creates a new immutable set for types that implement HasHashCode.
+//
+// Example:
+//
+//	v1 := collection.NewImmutableSetHashCode(user1)
+//	v2 := v1.Add(user2)
+//	v1.Length() // 1
+//	v2.Length() // 2
+func NewImmutableSetHashCode[T HasHashCode](elements ...T) ImmutableSetHashCode[T] {
+	return (&immutableSetHashCode[T]{}).Add(elements...)
+}
+
+// NewImmutableSetHashCodeFromSetHashCode creates a new immutable set containing all elements of set.
+func NewImmutableSetHashCodeFromSetHashCode[T HasHashCode](
+	set SetHashCode[T],
+) ImmutableSetHashCode[T] {
+	return NewImmutableSetHashCode(set.Slice()...)
+}
+
+type immutableSetHashCode[T HasHashCode] struct {
+	trie hamt[string, T]
+}
+
+func (s *immutableSetHashCode[T]) Add(elements ...T) ImmutableSetHashCode[T] {
+	owner := &hamtOwner{}
+	trie := s.trie
+	for _, element := range elements {
+		hashCode := element.HashCode()
+		hash := maphash.String(hamtSeed, hashCode)
+		present, found := trie.get(hash, hashCode)
+		if found && reflect.DeepEqual(present, element) {
+			continue
+		}
+		trie, _ = trie.put(owner, hash, hashCode, element, true)
+	}
+	if trie.root == s.trie.root {
+		return s
+	}
+	return &immutableSetHashCode[T]{trie: trie}
+}
+
+func (s *immutableSetHashCode[T]) Remove(elements ...T) ImmutableSetHashCode[T] {
+	owner := &hamtOwner{}
+	trie := s.trie
+	for _, element := range elements {
+		hashCode := element.HashCode()
+		trie, _ = trie.remove(owner, maphash.String(hamtSeed, hashCode), hashCode)
+	}
+	if trie.root == s.trie.root {
+		return s
+	}
+	return &immutableSetHashCode[T]{trie: trie}
+}
+
+func (s *immutableSetHashCode[T]) Contains(element T) bool {
+	hashCode := element.HashCode()
+	_, found := s.trie.get(maphash.String(hamtSeed, hashCode), hashCode)
+	return found
+}
+
+func (s *immutableSetHashCode[T]) ContainsAll(elements ...T) bool {
+	for _, element := range elements {
+		if !s.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *immutableSetHashCode[T]) ContainsAny(elements ...T) bool {
+	for _, element := range elements {
+		if s.Contains(element) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *immutableSetHashCode[T]) Slice() []T {
+	result := make([]T, 0, s.trie.size)
+	s.trie.each(func(_ string, value T) bool {
+		result = append(result, value)
+		return true
+	})
+	return result
+}
+
+func (s *immutableSetHashCode[T]) Length() int {
+	return s.trie.size
+}
+
+// Strings returns all elements as their string representations in sorted order.
+// This provides deterministic output suitable for debugging and logging.
+func (s *immutableSetHashCode[T]) Strings() []string {
+	result := make([]string, 0, s.trie.size)
+	s.trie.each(func(_ string, value T) bool {
+		result = append(result, elementToString(value))
+		return true
+	})
+	sort.Strings(result)
+	return result
+}
+
+// String returns a human-readable string representation of the set.
+// Format: "ImmutableSetHashCode[element1, element2, ...]" for non-empty sets, "ImmutableSetHashCode[]" for empty sets.
+func (s *immutableSetHashCode[T]) String() string {
+	return formatSetString("ImmutableSetHashCode[", s.Strings())
+}
+
+// Each calls fn for each element in the set. Iteration stops on first error.
+// The order of iteration is arbitrary and not guaranteed to be consistent.
+func (s *immutableSetHashCode[T]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	for element := range s.All() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := fn(ctx, element); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// All returns an iterator over the elements in arbitrary order.
+// The set can't change, so no snapshot is taken.
+func (s *immutableSetHashCode[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.trie.each(func(_ string, value T) bool {
+			return yield(value)
+		})
+	}
+}
+
+// Equal reports whether the set and other contain the same hash codes.
+func (s *immutableSetHashCode[T]) Equal(other ImmutableSetHashCode[T]) bool {
+	if o, ok := other.(*immutableSetHashCode[T]); ok {
+		return s.trie.equalKeys(o.trie)
+	}
+	if s.Length() != other.Length() {
+		return false
+	}
+	for element := range other.All() {
+		if !s.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// ToSetHashCode returns a new mutable SetHashCode containing all elements of the set.
+func (s *immutableSetHashCode[T]) ToSetHashCode() SetHashCode[T] {
+	return NewSetHashCode(s.Slice()...)
+}
+
+// MarshalJSON implements json.Marshaler for ImmutableSetHashCode.
+// It serializes the set as a JSON array of elements ordered by hash code.
+func (s *immutableSetHashCode[T]) MarshalJSON() ([]byte, error) {
+	hashCodes := make([]string, 0, s.trie.size)
+	s.trie.each(func(hashCode string, _ T) bool {
+		hashCodes = append(hashCodes, hashCode)
+		return true
+	})
+	sort.Strings(hashCodes)
+	elements := make([]T, 0, len(hashCodes))
+	for _, hashCode := range hashCodes {
+		element, _ := s.trie.get(maphash.String(hamtSeed, hashCode), hashCode)
+		elements = append(elements, element)
+	}
+	return json.Marshal(elements)
+}
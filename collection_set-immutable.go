@@ -0,0 +1,225 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"context"
+	"hash/maphash"
+	"iter"
+	"sort"
+)
+
+// ImmutableSet represents a persistent set for comparable types.
+// Add and Remove never modify the set but return a new version that shares all unchanged
+// parts with the old one, so keeping many slightly different versions of a large set is cheap.
+// An ImmutableSet is safe for concurrent use without locking.
+//
+// Performance: This implementation uses a hash array mapped trie (HAMT) with
+// O(log32 n) operations for Add, Remove and Contains. Equal skips all parts shared
+// between two versions, so comparing versions derived from each other is proportional
+// to their differences.
+type ImmutableSet[T comparable] interface {
+	// Add returns a new version of the set containing elements.
+	// The set itself is returned if all elements are already present.
+	Add(elements ...T) ImmutableSet[T]
+	// Remove returns a new version of the set without elements.
+	// The set itself is returned if none of the elements is present.
+	Remove(elements ...T) ImmutableSet[T]
+	// Contains reports whether an element is present in the set.
+	Contains(element T) bool
+	// ContainsAll reports whether all given elements are present in the set.
+	ContainsAll(elements ...T) bool
+	// ContainsAny reports whether at least one of the given elements is present in the set.
+	ContainsAny(elements ...T) bool
+	// Slice returns all elements as a slice. The order is arbitrary.
+	Slice() []T
+	// Length returns the number of elements in the set.
+	Length() int
+	// Strings returns all elements as their string representations in sorted order.
+	Strings() []string
+	// String returns a human-readable string representation of the set.
+	String() string
+	// Each calls fn for each element in the set. Iteration stops on first error.
+	Each(ctx context.Context, fn func(ctx context.Context, value T) error) error
+	// All returns an iterator over the elements in arbitrary order.
+	All() iter.Seq[T]
+	// Equal reports whether the set and other contain the same elements.
+	Equal(other ImmutableSet[T]) bool
+	// ToSet returns a new mutable Set containing all elements of the set.
+	ToSet() Set[T]
+	// MarshalText converts set elements to comma-separated text in a deterministic order.
+	// It implements encoding.TextMarshaler for automatic serialization.
+	MarshalText() ([]byte, error)
+	// MarshalJSON serializes set elements to a JSON array in a deterministic order.
+	// It implements json.Marshaler for automatic JSON serialization.
+	MarshalJSON() ([]byte, error)
+}
+
+// NewImmutableSet creates a new immutable set for comparable types.
+//
+// Example:
+//
+//	v1 := collection.NewImmutableSet("a", "b")
+//	v2 := v1.Add("c")
+//	v1.Contains("c") // false
+//	v2.Contains("c") // true
+func NewImmutableSet[T comparable](elements ...T) ImmutableSet[T] {
+	return (&immutableSet[T]{}).Add(elements...)
+}
+
+// NewImmutableSetFromSet creates a new immutable set containing all elements of set.
+func NewImmutableSetFromSet[T comparable](set Set[T]) ImmutableSet[T] {
+	return NewImmutableSet(set.Slice()...)
+}
+
+type immutableSet[T comparable] struct {
+	trie hamt[T, struct{}]
+}
+
+func immutableSetHash[T comparable](element T) uint64 {
+	return maphash.Comparable(hamtSeed, element)
+}
+
+func (s *immutableSet[T]) Add(elements ...T) ImmutableSet[T] {
+	owner := &hamtOwner{}
+	trie := s.trie
+	for _, element := range elements {
+		trie, _ = trie.put(owner, immutableSetHash(element), element, struct{}{}, false)
+	}
+	if trie.root == s.trie.root {
+		return s
+	}
+	return &immutableSet[T]{trie: trie}
+}
+
+func (s *immutableSet[T]) Remove(elements ...T) ImmutableSet[T] {
+	owner := &hamtOwner{}
+	trie := s.trie
+	for _, element := range elements {
+		trie, _ = trie.remove(owner, immutableSetHash(element), element)
+	}
+	if trie.root == s.trie.root {
+		return s
+	}
+	return &immutableSet[T]{trie: trie}
+}
+
+func (s *immutableSet[T]) Contains(element T) bool {
+	_, found := s.trie.get(immutableSetHash(element), element)
+	return found
+}
+
+func (s *immutableSet[T]) ContainsAll(elements ...T) bool {
+	for _, element := range elements {
+		if !s.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *immutableSet[T]) ContainsAny(elements ...T) bool {
+	for _, element := range elements {
+		if s.Contains(element) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *immutableSet[T]) Slice() []T {
+	result := make([]T, 0, s.trie.size)
+	s.trie.each(func(key T, _ struct{}) bool {
+		result = append(result, key)
+		return true
+	})
+	return result
+}
+
+func (s *immutableSet[T]) Length() int {
+	return s.trie.size
+}
+
+// Strings returns all elements as their string representations in sorted order.
+// This provides deterministic output suitable for debugging and logging.
+func (s *immutableSet[T]) Strings() []string {
+	result := make([]string, 0, s.trie.size)
+	s.trie.each(func(key T, _ struct{}) bool {
+		result = append(result, elementToString(key))
+		return true
+	})
+	sort.Strings(result)
+	return result
+}
+
+// String returns a human-readable string representation of the set.
+// Format: "ImmutableSet[element1, element2, ...]" for non-empty sets, "ImmutableSet[]" for empty sets.
+func (s *immutableSet[T]) String() string {
+	return formatSetString("ImmutableSet[", s.Strings())
+}
+
+// Each calls fn for each element in the set. Iteration stops on first error.
+// The order of iteration is arbitrary and not guaranteed to be consistent.
+func (s *immutableSet[T]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	for element := range s.All() {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := fn(ctx, element); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// All returns an iterator over the elements in arbitrary order.
+// The set can't change, so no snapshot is taken.
+func (s *immutableSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.trie.each(func(key T, _ struct{}) bool {
+			return yield(key)
+		})
+	}
+}
+
+// Equal reports whether the set and other contain the same elements.
+func (s *immutableSet[T]) Equal(other ImmutableSet[T]) bool {
+	if o, ok := other.(*immutableSet[T]); ok {
+		return s.trie.equalKeys(o.trie)
+	}
+	if s.Length() != other.Length() {
+		return false
+	}
+	for element := range other.All() {
+		if !s.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// ToSet returns a new mutable Set containing all elements of the set.
+func (s *immutableSet[T]) ToSet() Set[T] {
+	return NewSet(s.Slice()...)
+}
+
+// MarshalText implements encoding.TextMarshaler for ImmutableSet.
+// Elements are written in natural order for types based on string, int, uint or float,
+// or sorted by their string representation.
+func (s *immutableSet[T]) MarshalText() ([]byte, error) {
+	return marshalSortedText(s.Slice(), nil), nil
+}
+
+// MarshalJSON implements json.Marshaler for ImmutableSet.
+// Elements are written in natural order for types based on string, int, uint or float,
+// or sorted by their JSON encoding, so equal sets always produce the same output.
+func (s *immutableSet[T]) MarshalJSON() ([]byte, error) {
+	return marshalSortedJSON(s.Slice(), nil)
+}
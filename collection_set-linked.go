@@ -0,0 +1,518 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// LinkedSet represents a thread-safe set of comparable elements that keeps insertion order.
+// Each, Slice, All, MarshalJSON and MarshalText return elements in insertion order (FIFO).
+// Adding an element that is already present does not change its position.
+//
+// Performance: This implementation combines a map with a doubly linked list and provides
+// O(1) average-case operations for Add, Remove, Contains, MoveToFront and MoveToBack.
+type LinkedSet[T comparable] interface {
+	Set[T]
+	// MoveToFront moves an element to the start of the iteration order.
+	// It reports whether the element is present in the set.
+	MoveToFront(element T) bool
+	// MoveToBack moves an element to the end of the iteration order.
+	// It reports whether the element is present in the set.
+	MoveToBack(element T) bool
+}
+
+// NewLinkedSet creates a new thread-safe set for comparable types that keeps insertion order.
+// It accepts optional initial elements to populate the set.
+// Set operations like Clone, Without or Union return a Set that also keeps insertion order.
+//
+// Example:
+//
+//	set := collection.NewLinkedSet("b", "a", "c")
+//	set.Slice() // [b a c]
+func NewLinkedSet[T comparable](elements ...T) LinkedSet[T] {
+	return newLinkedSet(elements...)
+}
+
+func newLinkedSet[T comparable](elements ...T) *linkedSet[T] {
+	s := &linkedSet[T]{
+		index: make(map[T]*linkedListNode[T]),
+	}
+	s.Add(elements...)
+	return s
+}
+
+// ParseLinkedSetFromString parses a comma-separated string into a LinkedSet with string-based type.
+// Elements keep the order in which they appear in value.
+func ParseLinkedSetFromString[T ~string](value string) LinkedSet[T] {
+	parts := splitText(value)
+	result := make([]T, len(parts))
+	for i, part := range parts {
+		result[i] = T(part)
+	}
+	return NewLinkedSet(result...)
+}
+
+type linkedSet[T comparable] struct {
+	id       atomic.Uint64
+	mux      sync.Mutex
+	list     linkedList[T]
+	index    map[T]*linkedListNode[T]
+	notifier setNotifier[T]
+}
+
+func (s *linkedSet[T]) lockOrder() uint64 {
+	return setLockID(&s.id)
+}
+
+func (s *linkedSet[T]) lock() {
+	s.mux.Lock()
+}
+
+func (s *linkedSet[T]) unlock() {
+	s.mux.Unlock()
+}
+
+// releaseLocked unlocks the set and delivers the events to the subscribers.
+func (s *linkedSet[T]) releaseLocked(events []SetEvent[T]) {
+	s.notifier.release(&s.mux, events...)
+}
+
+func (s *linkedSet[T]) containsLocked(element T) bool {
+	_, found := s.index[element]
+	return found
+}
+
+func (s *linkedSet[T]) lengthLocked() int {
+	return s.list.length
+}
+
+func (s *linkedSet[T]) sliceLocked() []T {
+	return s.list.values()
+}
+
+// insert appends element if it is not present and reports whether it was added.
+func (s *linkedSet[T]) insert(element T) bool {
+	if _, found := s.index[element]; found {
+		return false
+	}
+	if s.index == nil {
+		s.index = make(map[T]*linkedListNode[T])
+	}
+	s.index[element] = s.list.pushBack(element)
+	return true
+}
+
+// delete removes element and reports whether it was present.
+func (s *linkedSet[T]) delete(element T) bool {
+	node, found := s.index[element]
+	if !found {
+		return false
+	}
+	s.list.remove(node)
+	delete(s.index, element)
+	return true
+}
+
+// reset removes all elements.
+func (s *linkedSet[T]) reset() {
+	s.list = linkedList[T]{}
+	s.index = make(map[T]*linkedListNode[T])
+}
+
+func (s *linkedSet[T]) Add(elements ...T) {
+	s.mux.Lock()
+	s.notifier.release(&s.mux, s.addLocked(elements)...)
+}
+
+// addLocked inserts elements and returns the event for the subscribers.
+func (s *linkedSet[T]) addLocked(elements []T) []SetEvent[T] {
+	track := s.notifier.hasSubscribers()
+	var added []T
+	for _, element := range elements {
+		if s.insert(element) && track {
+			added = append(added, element)
+		}
+	}
+	return []SetEvent[T]{{Type: SetEventAdded, Elements: added}}
+}
+
+func (s *linkedSet[T]) Remove(elements ...T) {
+	s.mux.Lock()
+	s.notifier.release(&s.mux, s.removeLocked(elements)...)
+}
+
+// removeLocked deletes elements and returns the event for the subscribers.
+func (s *linkedSet[T]) removeLocked(elements []T) []SetEvent[T] {
+	track := s.notifier.hasSubscribers()
+	var removed []T
+	for _, element := range elements {
+		if s.delete(element) && track {
+			removed = append(removed, element)
+		}
+	}
+	return []SetEvent[T]{{Type: SetEventRemoved, Elements: removed}}
+}
+
+// replace swaps the content of the set for elements and reports the changes to subscribers.
+func (s *linkedSet[T]) replace(elements []T) {
+	s.mux.Lock()
+	old := s.index
+	s.reset()
+	for _, element := range elements {
+		s.insert(element)
+	}
+	if !s.notifier.hasSubscribers() {
+		s.mux.Unlock()
+		return
+	}
+	var added, removed []T
+	for element := range old {
+		if _, found := s.index[element]; !found {
+			removed = append(removed, element)
+		}
+	}
+	for node := s.list.head; node != nil; node = node.next {
+		if _, found := old[node.value]; !found {
+			added = append(added, node.value)
+		}
+	}
+	s.notifier.release(
+		&s.mux,
+		SetEvent[T]{Type: SetEventRemoved, Elements: removed},
+		SetEvent[T]{Type: SetEventAdded, Elements: added},
+	)
+}
+
+func (s *linkedSet[T]) Update(fn func(tx SetTx[T]) error) error {
+	return updateSet(s, sameElement[T], nil, fn)
+}
+
+func (s *linkedSet[T]) AddIfAbsent(element T) bool {
+	return addIfAbsent(s.Update, element)
+}
+
+func (s *linkedSet[T]) RemoveIfPresent(element T) bool {
+	return removeIfPresent(s.Update, element)
+}
+
+func (s *linkedSet[T]) Replace(oldElement T, newElement T) bool {
+	return replaceElement(s.Update, oldElement, newElement)
+}
+
+func (s *linkedSet[T]) CompareAndSwap(expected []T, elements []T) bool {
+	return compareAndSwap(s.Update, expected, elements)
+}
+
+// Subscribe returns a channel that receives an event for every change of the set.
+// Only elements that were actually added or removed are reported.
+// The channel is closed once ctx is canceled.
+func (s *linkedSet[T]) Subscribe(ctx context.Context, options SubscribeOptions) <-chan SetEvent[T] {
+	return s.notifier.subscribe(ctx, options)
+}
+
+func (s *linkedSet[T]) Contains(element T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.containsLocked(element)
+}
+
+func (s *linkedSet[T]) ContainsAll(elements ...T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, element := range elements {
+		if !s.containsLocked(element) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *linkedSet[T]) ContainsAny(elements ...T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, element := range elements {
+		if s.containsLocked(element) {
+			return true
+		}
+	}
+	return false
+}
+
+// Slice returns all elements in insertion order.
+func (s *linkedSet[T]) Slice() []T {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.list.values()
+}
+
+func (s *linkedSet[T]) Length() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.list.length
+}
+
+// Strings returns all elements as their string representations in sorted order.
+// This provides deterministic output suitable for debugging and logging.
+func (s *linkedSet[T]) Strings() []string {
+	result := s.orderedStrings()
+	sort.Strings(result)
+	return result
+}
+
+// orderedStrings returns all elements as their string representations in insertion order.
+func (s *linkedSet[T]) orderedStrings() []string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	result := make([]string, 0, s.list.length)
+	for node := s.list.head; node != nil; node = node.next {
+		result = append(result, elementToString(node.value))
+	}
+	return result
+}
+
+// String returns a human-readable string representation of the set.
+// Format: "LinkedSet[element1, element2, ...]" in insertion order.
+func (s *linkedSet[T]) String() string {
+	return formatSetString("LinkedSet[", s.orderedStrings())
+}
+
+// Each calls fn for each element of a snapshot of the set. Iteration stops on first error.
+// fn may access and change the set; changes are not reflected in the iteration.
+// Elements are iterated in insertion order (FIFO).
+func (s *linkedSet[T]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return Each(ctx, s.Slice(), fn)
+}
+
+// EachLocked calls fn for each element while holding the lock of the set, so the set
+// can't change during the iteration. fn must not call methods of the set, which would
+// deadlock, and blocks all other callers while it runs.
+// Elements are iterated in insertion order (FIFO).
+func (s *linkedSet[T]) EachLocked(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for node := s.list.head; node != nil; node = node.next {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := fn(ctx, node.value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// EachParallel calls fn for each element of a snapshot of the set using up to workers
+// goroutines. The first error cancels the remaining calls and is returned.
+func (s *linkedSet[T]) EachParallel(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, false, fn)
+}
+
+// EachParallelCollectErrors calls fn for each element of a snapshot of the set using up to
+// workers goroutines and returns the errors of all failed calls joined.
+func (s *linkedSet[T]) EachParallelCollectErrors(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, true, fn)
+}
+
+// All returns an iterator over a snapshot of the elements in insertion order (FIFO).
+// Changes to the set during iteration are not reflected.
+func (s *linkedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range s.Slice() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a new Set containing all elements from the current set in insertion order.
+// The returned set is a shallow copy - modifications to it won't affect the original.
+func (s *linkedSet[T]) Clone() Set[T] {
+	return newLinkedSet(s.Slice()...)
+}
+
+// Without returns a new Set containing all elements from the current set
+// except those specified in the elements parameter, keeping insertion order.
+// The original set is not modified.
+func (s *linkedSet[T]) Without(elements ...T) Set[T] {
+	result := s.Clone()
+	result.Remove(elements...)
+	return result
+}
+
+func (s *linkedSet[T]) MoveToFront(element T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	node, found := s.index[element]
+	if !found {
+		return false
+	}
+	s.list.moveToFront(node)
+	return true
+}
+
+func (s *linkedSet[T]) MoveToBack(element T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	node, found := s.index[element]
+	if !found {
+		return false
+	}
+	s.list.moveToBack(node)
+	return true
+}
+
+// Union returns a new Set containing all elements of the current set followed by
+// the elements of other, keeping insertion order.
+func (s *linkedSet[T]) Union(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return newLinkedSet(unionLocked[T](sets)...)
+}
+
+// Intersection returns a new Set containing the elements present in both the current set
+// and other, in the insertion order of the current set.
+func (s *linkedSet[T]) Intersection(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return newLinkedSet(intersectionLocked[T](sets)...)
+}
+
+// Difference returns a new Set containing the elements of the current set not present in other,
+// in the insertion order of the current set.
+func (s *linkedSet[T]) Difference(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return newLinkedSet(differenceLocked[T](sets)...)
+}
+
+// SymmetricDifference returns a new Set containing the elements present in exactly one of
+// the current set and other.
+func (s *linkedSet[T]) SymmetricDifference(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return newLinkedSet(symmetricDifferenceLocked[T](sets)...)
+}
+
+// IsSubsetOf reports whether all elements of the current set are present in other.
+func (s *linkedSet[T]) IsSubsetOf(other Set[T]) bool {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isSubsetLocked[T](sets[0], sets[1])
+}
+
+// IsSupersetOf reports whether all elements of other are present in the current set.
+func (s *linkedSet[T]) IsSupersetOf(other Set[T]) bool {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isSubsetLocked[T](sets[1], sets[0])
+}
+
+// IsDisjoint reports whether the current set and other have no elements in common.
+func (s *linkedSet[T]) IsDisjoint(other Set[T]) bool {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isDisjointLocked[T](sets[0], sets[1])
+}
+
+// MarshalText implements encoding.TextMarshaler for LinkedSet.
+// Elements are written in insertion order.
+func (s *linkedSet[T]) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(s.orderedStrings(), ",")), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for LinkedSet.
+// Elements keep the order in which they appear in text.
+func (s *linkedSet[T]) UnmarshalText(text []byte) error {
+	parts := splitText(string(text))
+	elements := make([]T, 0, len(parts))
+	for _, part := range parts {
+		element, err := parseTextElement[T](part)
+		if err != nil {
+			return err
+		}
+		elements = append(elements, element)
+	}
+
+	s.replace(elements)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for LinkedSet.
+// It serializes the set as a JSON array of elements in insertion order.
+func (s *linkedSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Slice())
+}
+
+// UnmarshalJSON implements json.Unmarshaler for LinkedSet.
+// Elements keep the order in which they appear in the JSON array.
+func (s *linkedSet[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	s.replace(elements)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler for LinkedSet.
+// Elements are written in insertion order.
+func (s *linkedSet[T]) MarshalBinary() ([]byte, error) {
+	return marshalSetBinary(s.Slice())
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for LinkedSet.
+// The set is left unchanged if data is invalid.
+func (s *linkedSet[T]) UnmarshalBinary(data []byte) error {
+	elements, err := unmarshalSetBinary[T](data)
+	if err != nil {
+		return err
+	}
+	s.replace(elements)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder for LinkedSet using the binary format.
+func (s *linkedSet[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder for LinkedSet using the binary format.
+func (s *linkedSet[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
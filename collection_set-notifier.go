@@ -0,0 +1,194 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// SetEventType describes the kind of change of a SetEvent.
+type SetEventType string
+
+const (
+	// SetEventAdded is sent for elements that were not present before.
+	SetEventAdded SetEventType = "added"
+	// SetEventRemoved is sent for elements that were present before.
+	SetEventRemoved SetEventType = "removed"
+)
+
+// SetEvent describes a change of a set. Elements contains only the elements that actually changed,
+// so adding an element that is already present or removing a missing one produces no event.
+type SetEvent[T any] struct {
+	Type     SetEventType
+	Elements []T
+}
+
+// DeliveryMode controls what happens if a subscriber cannot keep up with the changes of a set.
+type DeliveryMode int
+
+const (
+	// DeliveryBlock queues events until the subscriber receives them, so no event is lost.
+	// Writers never wait for the subscriber; the queue of a slow subscriber grows instead.
+	DeliveryBlock DeliveryMode = iota
+	// DeliveryDrop discards the event if the buffer of the subscriber is full.
+	DeliveryDrop
+)
+
+// SubscribeOptions configure a subscription to set changes.
+type SubscribeOptions struct {
+	// BufferSize is the capacity of the returned channel.
+	BufferSize int
+	// Delivery selects between blocking and dropping if the channel is full.
+	Delivery DeliveryMode
+}
+
+// setNotifier delivers SetEvents to subscribers in the order of the changes.
+// The zero value is ready to use.
+type setNotifier[T any] struct {
+	mux         sync.Mutex
+	subscribers map[uint64]*setSubscription[T]
+	counter     uint64
+	active      atomic.Int64
+}
+
+// setSubscription sends events of DeliveryBlock through a queue drained by its own
+// goroutine, so the set and the notifier are never locked while waiting for the subscriber.
+type setSubscription[T any] struct {
+	ctx      context.Context
+	ch       chan SetEvent[T]
+	delivery DeliveryMode
+	mux      sync.Mutex
+	queue    []SetEvent[T]
+	signal   chan struct{}
+}
+
+// subscribe registers a new subscriber. The returned channel is closed once ctx is canceled.
+func (n *setNotifier[T]) subscribe(
+	ctx context.Context,
+	options SubscribeOptions,
+) <-chan SetEvent[T] {
+	subscription := &setSubscription[T]{
+		ctx:      ctx,
+		ch:       make(chan SetEvent[T], max(options.BufferSize, 0)),
+		delivery: options.Delivery,
+		signal:   make(chan struct{}, 1),
+	}
+
+	n.mux.Lock()
+	if n.subscribers == nil {
+		n.subscribers = make(map[uint64]*setSubscription[T])
+	}
+	n.counter++
+	id := n.counter
+	n.subscribers[id] = subscription
+	n.active.Add(1)
+	n.mux.Unlock()
+
+	go func() {
+		if subscription.delivery == DeliveryBlock {
+			subscription.deliver()
+		} else {
+			<-ctx.Done()
+		}
+		n.mux.Lock()
+		defer n.mux.Unlock()
+		delete(n.subscribers, id)
+		n.active.Add(-1)
+		close(subscription.ch)
+	}()
+	return subscription.ch
+}
+
+// hasSubscribers reports whether changes need to be tracked.
+func (n *setNotifier[T]) hasSubscribers() bool {
+	return n.active.Load() > 0
+}
+
+// release unlocks the set and delivers the events. The notifier is locked before the set
+// is unlocked, so subscribers receive events in the order the changes were applied.
+// Delivering never blocks, so subscribers may use the set while receiving events.
+func (n *setNotifier[T]) release(setMux *sync.Mutex, events ...SetEvent[T]) {
+	if !n.hasSubscribers() || !hasSetEventElements(events) {
+		setMux.Unlock()
+		return
+	}
+	n.mux.Lock()
+	setMux.Unlock()
+	defer n.mux.Unlock()
+
+	for _, event := range events {
+		if len(event.Elements) == 0 {
+			continue
+		}
+		for _, subscription := range n.subscribers {
+			subscription.send(event)
+		}
+	}
+}
+
+// send passes event to the subscriber without blocking. Events of DeliveryDrop are
+// discarded if the channel is full, events of DeliveryBlock are queued for deliver.
+func (s *setSubscription[T]) send(event SetEvent[T]) {
+	if s.delivery == DeliveryDrop {
+		select {
+		case s.ch <- event:
+		default:
+		}
+		return
+	}
+	s.mux.Lock()
+	s.queue = append(s.queue, event)
+	s.mux.Unlock()
+	select {
+	case s.signal <- struct{}{}:
+	default:
+	}
+}
+
+// deliver sends the queued events to the subscriber until ctx is canceled.
+func (s *setSubscription[T]) deliver() {
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-s.signal:
+		}
+		for {
+			event, ok := s.next()
+			if !ok {
+				break
+			}
+			select {
+			case <-s.ctx.Done():
+				return
+			case s.ch <- event:
+			}
+		}
+	}
+}
+
+// next removes the oldest event from the queue.
+func (s *setSubscription[T]) next() (SetEvent[T], bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	if len(s.queue) == 0 {
+		return SetEvent[T]{}, false
+	}
+	event := s.queue[0]
+	s.queue[0] = SetEvent[T]{}
+	s.queue = s.queue[1:]
+	return event, true
+}
+
+func hasSetEventElements[T any](events []SetEvent[T]) bool {
+	for _, event := range events {
+		if len(event.Elements) > 0 {
+			return true
+		}
+	}
+	return false
+}
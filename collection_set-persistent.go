@@ -0,0 +1,566 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/bborbe/errors"
+)
+
+// FsyncPolicy defines when a PersistentSet flushes its log to disk.
+type FsyncPolicy int
+
+const (
+	// FsyncAlways syncs the log after every change, so no acknowledged change is lost
+	// if the machine crashes.
+	FsyncAlways FsyncPolicy = iota
+	// FsyncNever leaves syncing the log to the operating system. Changes survive a crash
+	// of the process but the last changes may be lost if the machine crashes.
+	// Sync, Compact and Close still sync the log.
+	FsyncNever
+)
+
+// PersistentSetOptions configures a PersistentSet.
+type PersistentSetOptions[T any] struct {
+	// Fsync defines when the log is flushed to disk. Defaults to FsyncAlways.
+	Fsync FsyncPolicy
+	// Codec converts elements to bytes and back. Defaults to NewJSONElementCodec.
+	Codec ElementCodec[T]
+}
+
+// PersistentSet represents a thread-safe Set backed by files on the local filesystem.
+// Every Add and Remove is appended to a log before it is applied, and Compact replaces
+// the log with a snapshot of the set. On creation the set is recovered from the snapshot
+// and the log, so the content survives restarts and crashes.
+//
+// Add and Remove can't return errors; the first error writing the log is kept and
+// returned by Err, Sync, Compact and Close. Clone, Without and the set algebra
+// return in-memory sets.
+type PersistentSet[T comparable] interface {
+	Set[T]
+	// Compact writes a snapshot of the set and empties the log.
+	Compact() error
+	// Run calls Compact every interval until ctx is canceled.
+	// It returns nil once ctx is canceled or the error of Compact.
+	Run(ctx context.Context, interval time.Duration) error
+	// Sync flushes the log to disk.
+	Sync() error
+	// Err returns the first error writing the log, or nil.
+	Err() error
+	// Close syncs and closes the log. Changes after Close are kept in memory only
+	// and reported by Err.
+	Close() error
+}
+
+// NewPersistentSet opens the set stored at path, creating it if it doesn't exist.
+// The log is written to path and snapshots to path + ".snapshot". A log ending in a
+// partially written change after a crash is truncated to the last complete change.
+// A corrupt change followed by more changes is an error, so no change is dropped silently.
+//
+// Example:
+//
+//	allowlist, err := collection.NewPersistentSet(
+//		ctx,
+//		"allowlist.log",
+//		collection.PersistentSetOptions[string]{Fsync: collection.FsyncAlways},
+//	)
+//	defer allowlist.Close()
+//	allowlist.Add("alice")
+func NewPersistentSet[T comparable](
+	ctx context.Context,
+	path string,
+	options PersistentSetOptions[T],
+) (PersistentSet[T], error) {
+	if options.Codec == nil {
+		options.Codec = NewJSONElementCodec[T]()
+	}
+	s := &persistentSet[T]{
+		set:     newSet[T](),
+		path:    path,
+		options: options,
+	}
+	if err := s.loadSnapshot(ctx); err != nil {
+		return nil, err
+	}
+	if err := s.openLog(ctx); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// persistentSet keeps the elements in an embedded set. logMux serializes all changes,
+// so the order of the log matches the order in which changes are applied.
+type persistentSet[T comparable] struct {
+	*set[T]
+	logMux  sync.Mutex
+	path    string
+	options PersistentSetOptions[T]
+	file    *os.File
+	err     error
+}
+
+// A log and a snapshot file start with persistentSetBinaryVersion followed by records
+//
+//	op      byte    persistentSetOpAdd or persistentSetOpRemove
+//	length  uvarint
+//	payload length bytes of the encoded element
+//	crc     uint32  little endian CRC-32 of op and payload
+//
+// A snapshot contains only persistentSetOpAdd records.
+const (
+	persistentSetBinaryVersion byte = 1
+	persistentSetOpAdd         byte = 1
+	persistentSetOpRemove      byte = 2
+)
+
+// appendRecord appends the record of op for element to buf.
+func (s *persistentSet[T]) appendRecord(buf []byte, op byte, element T) ([]byte, error) {
+	payload, err := s.options.Codec.Encode(element)
+	if err != nil {
+		return buf, err
+	}
+	buf = append(buf, op)
+	buf = binary.AppendUvarint(buf, uint64(len(payload)))
+	buf = append(buf, payload...)
+	crc := crc32.Update(crc32.ChecksumIEEE([]byte{op}), crc32.IEEETable, payload)
+	return binary.LittleEndian.AppendUint32(buf, crc), nil
+}
+
+// readRecords applies the records of data to the set and returns the length of the
+// valid prefix of data. It stops at an incomplete or corrupt last record, which an
+// interrupted append leaves behind. A corrupt record followed by more data is an error.
+func (s *persistentSet[T]) readRecords(ctx context.Context, data []byte) (int, error) {
+	reader := bytes.NewReader(data)
+	valid := 0
+	for reader.Len() > 0 {
+		op, payload, err := readPersistentSetRecord(reader)
+		if err != nil {
+			if reader.Len() == 0 || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return valid, nil
+			}
+			return valid, errors.Wrapf(ctx, err, "record at offset %d corrupt", valid)
+		}
+		element, err := s.options.Codec.Decode(payload)
+		if err != nil {
+			return valid, errors.Wrapf(ctx, err, "decode record at offset %d failed", valid)
+		}
+		switch op {
+		case persistentSetOpAdd:
+			s.set.Add(element)
+		case persistentSetOpRemove:
+			s.set.Remove(element)
+		}
+		valid = len(data) - reader.Len()
+	}
+	return valid, nil
+}
+
+// readPersistentSetRecord reads the next record and verifies its checksum.
+func readPersistentSetRecord(reader *bytes.Reader) (byte, []byte, error) {
+	ctx := context.Background()
+	op, err := reader.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	if op != persistentSetOpAdd && op != persistentSetOpRemove {
+		return 0, nil, errors.Errorf(ctx, "invalid op %d", op)
+	}
+	length, err := binary.ReadUvarint(reader)
+	if err != nil {
+		return 0, nil, err
+	}
+	if length > uint64(reader.Len()) {
+		return 0, nil, io.ErrUnexpectedEOF
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return 0, nil, err
+	}
+	var crc uint32
+	if err := binary.Read(reader, binary.LittleEndian, &crc); err != nil {
+		return 0, nil, err
+	}
+	if crc != crc32.Update(crc32.ChecksumIEEE([]byte{op}), crc32.IEEETable, payload) {
+		return 0, nil, errors.New(ctx, "checksum mismatch")
+	}
+	return op, payload, nil
+}
+
+func (s *persistentSet[T]) snapshotPath() string {
+	return s.path + ".snapshot"
+}
+
+// loadSnapshot adds the elements of the snapshot to the set if a snapshot exists.
+// Snapshots are written atomically, so an incomplete snapshot is an error.
+func (s *persistentSet[T]) loadSnapshot(ctx context.Context) error {
+	// #nosec G304 -- the path of the set is chosen by the caller
+	data, err := os.ReadFile(s.snapshotPath())
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrapf(ctx, err, "read snapshot failed")
+	}
+	if len(data) < 1 || data[0] != persistentSetBinaryVersion {
+		return errors.Errorf(ctx, "unsupported snapshot version")
+	}
+	valid, err := s.readRecords(ctx, data[1:])
+	if err != nil {
+		return err
+	}
+	if valid != len(data)-1 {
+		return errors.Errorf(ctx, "snapshot corrupt at offset %d", valid+1)
+	}
+	return nil
+}
+
+// openLog replays the log, truncates a partially written last record and keeps
+// the log open for appending.
+func (s *persistentSet[T]) openLog(ctx context.Context) error {
+	// #nosec G304 -- the path of the set is chosen by the caller
+	file, err := os.OpenFile(s.path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "open log failed")
+	}
+	if err := s.replayLog(ctx, file); err != nil {
+		_ = file.Close()
+		return err
+	}
+	s.file = file
+	return nil
+}
+
+func (s *persistentSet[T]) replayLog(ctx context.Context, file *os.File) error {
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "read log failed")
+	}
+	if len(data) == 0 {
+		return s.writeLogHeader(ctx, file)
+	}
+	if data[0] != persistentSetBinaryVersion {
+		return errors.Errorf(ctx, "unsupported log version %d", data[0])
+	}
+	valid, err := s.readRecords(ctx, data[1:])
+	if err != nil {
+		return err
+	}
+	if valid == len(data)-1 {
+		return nil
+	}
+	if err := file.Truncate(int64(valid + 1)); err != nil {
+		return errors.Wrapf(ctx, err, "truncate incomplete log failed")
+	}
+	return errors.Wrapf(ctx, file.Sync(), "sync log failed")
+}
+
+func (s *persistentSet[T]) writeLogHeader(ctx context.Context, file *os.File) error {
+	if _, err := file.Write([]byte{persistentSetBinaryVersion}); err != nil {
+		return errors.Wrapf(ctx, err, "write log header failed")
+	}
+	return errors.Wrapf(ctx, file.Sync(), "sync log failed")
+}
+
+// logLocked appends the changes to the log. The first error is kept in s.err.
+func (s *persistentSet[T]) logLocked(op byte, elements []T) {
+	if len(elements) == 0 || s.err != nil {
+		return
+	}
+	ctx := context.Background()
+	if s.file == nil {
+		s.err = errors.New(ctx, "persistent set closed")
+		return
+	}
+	var buf []byte
+	for _, element := range elements {
+		var err error
+		if buf, err = s.appendRecord(buf, op, element); err != nil {
+			s.err = errors.Wrapf(ctx, err, "encode log record failed")
+			return
+		}
+	}
+	if _, err := s.file.Write(buf); err != nil {
+		s.err = errors.Wrapf(ctx, err, "write log failed")
+		return
+	}
+	if s.options.Fsync == FsyncAlways {
+		if err := s.file.Sync(); err != nil {
+			s.err = errors.Wrapf(ctx, err, "sync log failed")
+		}
+	}
+}
+
+func (s *persistentSet[T]) Add(elements ...T) {
+	s.logMux.Lock()
+	defer s.logMux.Unlock()
+
+	added := make([]T, 0, len(elements))
+	for _, element := range elements {
+		if !s.set.Contains(element) {
+			added = append(added, element)
+		}
+	}
+	s.logLocked(persistentSetOpAdd, added)
+	s.set.Add(added...)
+}
+
+func (s *persistentSet[T]) Remove(elements ...T) {
+	s.logMux.Lock()
+	defer s.logMux.Unlock()
+
+	removed := make([]T, 0, len(elements))
+	for _, element := range elements {
+		if s.set.Contains(element) {
+			removed = append(removed, element)
+		}
+	}
+	s.logLocked(persistentSetOpRemove, removed)
+	s.set.Remove(removed...)
+}
+
+// replace swaps the content of the set for elements and logs the changes.
+func (s *persistentSet[T]) replace(elements []T) {
+	s.logMux.Lock()
+	defer s.logMux.Unlock()
+
+	next := NewSet(elements...)
+	var removed []T
+	for _, element := range s.set.Slice() {
+		if !next.Contains(element) {
+			removed = append(removed, element)
+		}
+	}
+	var added []T
+	for _, element := range next.Slice() {
+		if !s.set.Contains(element) {
+			added = append(added, element)
+		}
+	}
+	s.logLocked(persistentSetOpRemove, removed)
+	s.logLocked(persistentSetOpAdd, added)
+	s.set.replace(elements)
+}
+
+// Update calls fn with a transaction holding the lock of the set. The changes made through
+// the transaction are logged and applied together if fn returns nil and discarded otherwise.
+// fn must not call methods of the set, which would deadlock.
+func (s *persistentSet[T]) Update(fn func(tx SetTx[T]) error) error {
+	s.logMux.Lock()
+	defer s.logMux.Unlock()
+
+	s.set.lock()
+	var events []SetEvent[T]
+	defer func() {
+		s.set.releaseLocked(events)
+	}()
+	removed, added, err := runSetTx(s.set, sameElement[T], nil, fn)
+	if err != nil {
+		return err
+	}
+	logged := make([]T, 0, len(added))
+	for _, element := range added {
+		if !s.set.containsLocked(element) {
+			logged = append(logged, element)
+		}
+	}
+	s.logLocked(persistentSetOpRemove, removed)
+	s.logLocked(persistentSetOpAdd, logged)
+	events = commitSetTx(s.set, removed, added)
+	return nil
+}
+
+// AddIfAbsent, RemoveIfPresent, Replace and CompareAndSwap are overridden, so they run
+// through the Update of the persistent set, which writes the log.
+func (s *persistentSet[T]) AddIfAbsent(element T) bool {
+	return addIfAbsent(s.Update, element)
+}
+
+func (s *persistentSet[T]) RemoveIfPresent(element T) bool {
+	return removeIfPresent(s.Update, element)
+}
+
+func (s *persistentSet[T]) Replace(oldElement T, newElement T) bool {
+	return replaceElement(s.Update, oldElement, newElement)
+}
+
+func (s *persistentSet[T]) CompareAndSwap(expected []T, elements []T) bool {
+	return compareAndSwap(s.Update, expected, elements)
+}
+
+// Compact writes a snapshot of the set and empties the log.
+// The snapshot is written to a temporary file and renamed, so a crash never leaves
+// an incomplete snapshot behind.
+func (s *persistentSet[T]) Compact() error {
+	s.logMux.Lock()
+	defer s.logMux.Unlock()
+
+	ctx := context.Background()
+	if s.err != nil {
+		return s.err
+	}
+	if s.file == nil {
+		return errors.New(ctx, "persistent set closed")
+	}
+	buf := []byte{persistentSetBinaryVersion}
+	for _, element := range s.set.Slice() {
+		var err error
+		if buf, err = s.appendRecord(buf, persistentSetOpAdd, element); err != nil {
+			return errors.Wrapf(ctx, err, "encode snapshot record failed")
+		}
+	}
+	if err := writeFileAtomic(ctx, s.snapshotPath(), buf); err != nil {
+		return err
+	}
+	// replaying the log on top of the snapshot gives the same set, so a crash
+	// before the log is truncated loses nothing
+	if err := s.file.Truncate(1); err != nil {
+		return errors.Wrapf(ctx, err, "truncate log failed")
+	}
+	return errors.Wrapf(ctx, s.file.Sync(), "sync log failed")
+}
+
+// writeFileAtomic writes data to a temporary file, syncs it and renames it to path.
+func writeFileAtomic(ctx context.Context, path string, data []byte) error {
+	tmp := path + ".tmp"
+	// #nosec G304 -- the path of the set is chosen by the caller
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return errors.Wrapf(ctx, err, "create %s failed", tmp)
+	}
+	if _, err := file.Write(data); err != nil {
+		_ = file.Close()
+		return errors.Wrapf(ctx, err, "write %s failed", tmp)
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return errors.Wrapf(ctx, err, "sync %s failed", tmp)
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(ctx, err, "close %s failed", tmp)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return errors.Wrapf(ctx, err, "rename %s failed", tmp)
+	}
+	// #nosec G304 -- the directory of the set is chosen by the caller
+	dir, err := os.Open(filepath.Dir(path))
+	if err != nil {
+		return errors.Wrapf(ctx, err, "open directory failed")
+	}
+	if err := dir.Sync(); err != nil {
+		_ = dir.Close()
+		return errors.Wrapf(ctx, err, "sync directory failed")
+	}
+	return errors.Wrapf(ctx, dir.Close(), "close directory failed")
+}
+
+// Run calls Compact every interval until ctx is canceled.
+// It returns nil once ctx is canceled or the error of Compact.
+func (s *persistentSet[T]) Run(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.Compact(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (s *persistentSet[T]) Sync() error {
+	s.logMux.Lock()
+	defer s.logMux.Unlock()
+
+	if s.err != nil {
+		return s.err
+	}
+	if s.file == nil {
+		return errors.New(context.Background(), "persistent set closed")
+	}
+	return errors.Wrapf(context.Background(), s.file.Sync(), "sync log failed")
+}
+
+func (s *persistentSet[T]) Err() error {
+	s.logMux.Lock()
+	defer s.logMux.Unlock()
+
+	return s.err
+}
+
+func (s *persistentSet[T]) Close() error {
+	s.logMux.Lock()
+	defer s.logMux.Unlock()
+
+	if s.file == nil {
+		return s.err
+	}
+	ctx := context.Background()
+	file := s.file
+	s.file = nil
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return errors.Wrapf(ctx, err, "sync log failed")
+	}
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(ctx, err, "close log failed")
+	}
+	return s.err
+}
+
+// String returns a human-readable string representation of the set.
+// Format: "PersistentSet[element1, element2, ...]" sorted by the string representation.
+func (s *persistentSet[T]) String() string {
+	return formatSetString("PersistentSet[", s.Strings())
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for PersistentSet.
+// The changes are written to the log.
+func (s *persistentSet[T]) UnmarshalText(text []byte) error {
+	decoded := newSet[T]()
+	if err := decoded.UnmarshalText(text); err != nil {
+		return err
+	}
+	s.replace(decoded.Slice())
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler for PersistentSet.
+// The changes are written to the log.
+func (s *persistentSet[T]) UnmarshalJSON(data []byte) error {
+	decoded := newSet[T]()
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	s.replace(decoded.Slice())
+	return nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for PersistentSet.
+// The changes are written to the log.
+func (s *persistentSet[T]) UnmarshalBinary(data []byte) error {
+	decoded := newSet[T]()
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		return err
+	}
+	s.replace(decoded.Slice())
+	return nil
+}
+
+// GobDecode implements gob.GobDecoder for PersistentSet using the binary format.
+func (s *persistentSet[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
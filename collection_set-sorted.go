@@ -0,0 +1,421 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"iter"
+	"strings"
+	"sync"
+)
+
+// SortedSet represents a thread-safe set that keeps its elements in ascending order.
+// Elements are uniquely identified by the compare function of the set:
+// two elements for which compare returns 0 are considered equal.
+//
+// Performance: This implementation uses a balanced binary search tree (AVL) with
+// O(log n) operations for Add, Remove, Contains, Min, Max, Floor, Ceiling and Rank.
+type SortedSet[T any] interface {
+	// Add inserts elements into the set. Duplicate elements are automatically ignored.
+	// Multiple elements can be added in a single call with only one mutex lock.
+	Add(elements ...T)
+	// Remove deletes elements from the set.
+	// Multiple elements can be removed in a single call with only one mutex lock.
+	Remove(elements ...T)
+	// Contains reports whether an element is present in the set.
+	Contains(element T) bool
+	// ContainsAll reports whether all given elements are present in the set.
+	ContainsAll(elements ...T) bool
+	// ContainsAny reports whether at least one of the given elements is present in the set.
+	ContainsAny(elements ...T) bool
+	// Slice returns all elements as a slice in ascending order.
+	Slice() []T
+	// Length returns the number of elements in the set.
+	Length() int
+	// Strings returns all elements as their string representations in ascending element order.
+	Strings() []string
+	// String returns a human-readable string representation of the set.
+	String() string
+	// Each calls fn for each element of a snapshot of the set. Iteration stops on first error.
+	// fn may access and change the set; changes are not reflected in the iteration.
+	// Elements are iterated in ascending order.
+	Each(ctx context.Context, fn func(ctx context.Context, value T) error) error
+	// EachLocked calls fn for each element while holding the lock of the set, so the set
+	// can't change during the iteration. fn must not call methods of the set, which would
+	// deadlock, and blocks all other callers while it runs.
+	// Elements are iterated in ascending order.
+	EachLocked(ctx context.Context, fn func(ctx context.Context, value T) error) error
+	// All returns an iterator over a snapshot of the elements in ascending order.
+	// Changes to the set during iteration are not reflected.
+	All() iter.Seq[T]
+	// Clone returns a new SortedSet containing all elements from the current set.
+	// The returned set is a shallow copy - modifications to it won't affect the original.
+	Clone() SortedSet[T]
+	// Without returns a new SortedSet containing all elements from the current set
+	// except those specified in the elements parameter.
+	// The original set is not modified.
+	Without(elements ...T) SortedSet[T]
+	// Min returns the smallest element. It returns false if the set is empty.
+	Min() (T, bool)
+	// Max returns the largest element. It returns false if the set is empty.
+	Max() (T, bool)
+	// Floor returns the largest element less than or equal to the given element.
+	// It returns false if no such element exists.
+	Floor(element T) (T, bool)
+	// Ceiling returns the smallest element greater than or equal to the given element.
+	// It returns false if no such element exists.
+	Ceiling(element T) (T, bool)
+	// Range returns all elements e with from <= e < to in ascending order.
+	Range(from T, to T) []T
+	// Rank returns the number of elements less than the given element.
+	// For an element of the set this is its zero-based position in ascending order.
+	Rank(element T) int
+	// UnmarshalText parses comma-separated text into set elements.
+	// It implements encoding.TextUnmarshaler for automatic parsing with argument packages.
+	UnmarshalText(text []byte) error
+	// MarshalText converts set elements to comma-separated text in ascending order.
+	// It implements encoding.TextMarshaler for automatic serialization.
+	MarshalText() ([]byte, error)
+	// UnmarshalJSON deserializes a JSON array into set elements.
+	// It implements json.Unmarshaler for automatic JSON parsing.
+	UnmarshalJSON(data []byte) error
+	// MarshalJSON serializes set elements to a JSON array in ascending order.
+	// It implements json.Marshaler for automatic JSON serialization.
+	MarshalJSON() ([]byte, error)
+	// Subscribe returns a channel that receives an event for every change of the set.
+	// Only elements that were actually added or removed are reported.
+	// The channel is closed once ctx is canceled.
+	Subscribe(ctx context.Context, options SubscribeOptions) <-chan SetEvent[T]
+}
+
+// NewSortedSet creates a new thread-safe sorted set for ordered types.
+// Elements are kept in their natural order as defined by cmp.Compare.
+//
+// Example:
+//
+//	set := collection.NewSortedSet(10, 2, 33)
+//	set.Slice() // [2 10 33]
+func NewSortedSet[T cmp.Ordered](elements ...T) SortedSet[T] {
+	return NewSortedSetFunc(cmp.Compare[T], elements...)
+}
+
+// NewSortedSetFunc creates a new thread-safe sorted set ordered by the given compare function.
+// compare must return a negative number if a < b, a positive number if a > b and zero if a and b
+// are equal. Elements for which compare returns zero are treated as the same element.
+//
+// Example:
+//
+//	byID := func(a, b User) int { return cmp.Compare(a.ID, b.ID) }
+//	set := collection.NewSortedSetFunc(byID, User{ID: 2}, User{ID: 1})
+func NewSortedSetFunc[T any](compare func(a, b T) int, elements ...T) SortedSet[T] {
+	s := &sortedSet[T]{
+		tree: sortedTree[T]{
+			compare: compare,
+		},
+	}
+	s.Add(elements...)
+	return s
+}
+
+type sortedSet[T any] struct {
+	mux      sync.Mutex
+	tree     sortedTree[T]
+	notifier setNotifier[T]
+}
+
+func (s *sortedSet[T]) Add(elements ...T) {
+	s.mux.Lock()
+	track := s.notifier.hasSubscribers()
+	var added []T
+	for _, element := range elements {
+		if s.tree.insert(element) && track {
+			added = append(added, element)
+		}
+	}
+	s.notifier.release(&s.mux, SetEvent[T]{Type: SetEventAdded, Elements: added})
+}
+
+func (s *sortedSet[T]) Remove(elements ...T) {
+	s.mux.Lock()
+	track := s.notifier.hasSubscribers()
+	var removed []T
+	for _, element := range elements {
+		existing, found := s.tree.floor(element)
+		if !found || s.tree.compare(existing, element) != 0 {
+			continue
+		}
+		s.tree.delete(element)
+		if track {
+			removed = append(removed, existing)
+		}
+	}
+	s.notifier.release(&s.mux, SetEvent[T]{Type: SetEventRemoved, Elements: removed})
+}
+
+// replace swaps the content of the set for elements and reports the changes to subscribers.
+func (s *sortedSet[T]) replace(elements []T) {
+	s.mux.Lock()
+	old := sortedTree[T]{compare: s.tree.compare, root: s.tree.root}
+	s.tree.root = nil
+	for _, element := range elements {
+		s.tree.insert(element)
+	}
+	if !s.notifier.hasSubscribers() {
+		s.mux.Unlock()
+		return
+	}
+	var added, removed []T
+	old.ascend(func(value T) bool {
+		if !s.tree.contains(value) {
+			removed = append(removed, value)
+		}
+		return true
+	})
+	s.tree.ascend(func(value T) bool {
+		if !old.contains(value) {
+			added = append(added, value)
+		}
+		return true
+	})
+	s.notifier.release(
+		&s.mux,
+		SetEvent[T]{Type: SetEventRemoved, Elements: removed},
+		SetEvent[T]{Type: SetEventAdded, Elements: added},
+	)
+}
+
+// Subscribe returns a channel that receives an event for every change of the set.
+// Only elements that were actually added or removed are reported.
+// The channel is closed once ctx is canceled.
+func (s *sortedSet[T]) Subscribe(ctx context.Context, options SubscribeOptions) <-chan SetEvent[T] {
+	return s.notifier.subscribe(ctx, options)
+}
+
+func (s *sortedSet[T]) Contains(element T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.tree.contains(element)
+}
+
+func (s *sortedSet[T]) ContainsAll(elements ...T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, element := range elements {
+		if !s.tree.contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *sortedSet[T]) ContainsAny(elements ...T) bool {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	for _, element := range elements {
+		if s.tree.contains(element) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *sortedSet[T]) Slice() []T {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.tree.values()
+}
+
+func (s *sortedSet[T]) Length() int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.tree.length()
+}
+
+// Strings returns all elements as their string representations in ascending element order.
+func (s *sortedSet[T]) Strings() []string {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	result := make([]string, 0, s.tree.length())
+	s.tree.ascend(func(value T) bool {
+		result = append(result, elementToString(value))
+		return true
+	})
+	return result
+}
+
+// String returns a human-readable string representation of the set.
+// Format: "SortedSet[element1, element2, ...]" for non-empty sets, "SortedSet[]" for empty sets.
+func (s *sortedSet[T]) String() string {
+	return formatSetString("SortedSet[", s.Strings())
+}
+
+// Each calls fn for each element of a snapshot of the set. Iteration stops on first error.
+// fn may access and change the set; changes are not reflected in the iteration.
+// Elements are iterated in ascending order.
+func (s *sortedSet[T]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return Each(ctx, s.Slice(), fn)
+}
+
+// EachLocked calls fn for each element while holding the lock of the set, so the set
+// can't change during the iteration. fn must not call methods of the set, which would
+// deadlock, and blocks all other callers while it runs.
+// Elements are iterated in ascending order.
+func (s *sortedSet[T]) EachLocked(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	var err error
+	s.tree.ascend(func(value T) bool {
+		select {
+		case <-ctx.Done():
+			err = ctx.Err()
+			return false
+		default:
+			err = fn(ctx, value)
+			return err == nil
+		}
+	})
+	return err
+}
+
+// All returns an iterator over a snapshot of the elements in ascending order.
+// Changes to the set during iteration are not reflected.
+func (s *sortedSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range s.Slice() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Clone returns a new SortedSet containing all elements from the current set.
+// The returned set is a shallow copy - modifications to it won't affect the original.
+func (s *sortedSet[T]) Clone() SortedSet[T] {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return &sortedSet[T]{
+		tree: sortedTree[T]{
+			compare: s.tree.compare,
+			root:    s.tree.root.clone(),
+		},
+	}
+}
+
+// Without returns a new SortedSet containing all elements from the current set
+// except those specified in the elements parameter.
+// The original set is not modified.
+func (s *sortedSet[T]) Without(elements ...T) SortedSet[T] {
+	result := s.Clone()
+	result.Remove(elements...)
+	return result
+}
+
+func (s *sortedSet[T]) Min() (T, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.tree.min()
+}
+
+func (s *sortedSet[T]) Max() (T, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.tree.max()
+}
+
+func (s *sortedSet[T]) Floor(element T) (T, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.tree.floor(element)
+}
+
+func (s *sortedSet[T]) Ceiling(element T) (T, bool) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.tree.ceiling(element)
+}
+
+// Range returns all elements e with from <= e < to in ascending order.
+func (s *sortedSet[T]) Range(from T, to T) []T {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	result := make([]T, 0)
+	s.tree.ascendRange(from, to, func(value T) bool {
+		result = append(result, value)
+		return true
+	})
+	return result
+}
+
+func (s *sortedSet[T]) Rank(element T) int {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	return s.tree.rank(element)
+}
+
+// MarshalText implements encoding.TextMarshaler for SortedSet.
+// Elements are written in ascending order.
+func (s *sortedSet[T]) MarshalText() ([]byte, error) {
+	return []byte(strings.Join(s.Strings(), ",")), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for SortedSet.
+// Each comma-separated part is converted using encoding.TextUnmarshaler of the element,
+// time.ParseDuration for time.Duration or directly for types based on string, bool, int,
+// uint and float.
+func (s *sortedSet[T]) UnmarshalText(text []byte) error {
+	parts := splitText(string(text))
+	elements := make([]T, 0, len(parts))
+	for _, part := range parts {
+		element, err := parseTextElement[T](part)
+		if err != nil {
+			return err
+		}
+		elements = append(elements, element)
+	}
+
+	s.replace(elements)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for SortedSet.
+// It serializes the set as a JSON array of elements in ascending order.
+func (s *sortedSet[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.Slice())
+}
+
+// UnmarshalJSON implements json.Unmarshaler for SortedSet.
+// It deserializes a JSON array into set elements.
+func (s *sortedSet[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	s.replace(elements)
+	return nil
+}
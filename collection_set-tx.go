@@ -0,0 +1,291 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	stderrors "errors"
+	"reflect"
+)
+
+// SetTx gives access to a set within Update. Reads see the changes made through the
+// transaction. The changes are applied to the set only if the function passed to Update
+// returns nil; side effects of applying them, like evictions of a BoundedSet, happen then.
+type SetTx[T any] interface {
+	// Add inserts elements into the set.
+	Add(elements ...T)
+	// Remove deletes elements from the set.
+	Remove(elements ...T)
+	// Contains reports whether an element is present in the set.
+	Contains(element T) bool
+	// Length returns the number of elements in the set.
+	Length() int
+	// Slice returns all elements as a slice.
+	Slice() []T
+}
+
+// errSetTxMismatch rolls back the transaction of CompareAndSwap if the elements differ.
+var errSetTxMismatch = stderrors.New("set elements mismatch")
+
+// txSet is a lockedSet that can be changed while its lock is held.
+type txSet[T any] interface {
+	lockedSet[T]
+	lengthLocked() int
+	// releaseLocked unlocks the set and delivers events to the subscribers.
+	releaseLocked(events []SetEvent[T])
+	// addLocked inserts elements and returns the events for the subscribers.
+	addLocked(elements []T) []SetEvent[T]
+	// removeLocked deletes elements and returns the events for the subscribers.
+	removeLocked(elements []T) []SetEvent[T]
+}
+
+// valueSet is implemented by txSets whose equal elements may differ in value.
+type valueSet[T any] interface {
+	// getLocked returns the present element equal to element.
+	getLocked(element T) (T, bool)
+}
+
+// setTxChange is the state of an element changed within a transaction.
+type setTxChange[T any] struct {
+	element T
+	// stored is the element present before the transaction.
+	stored     T
+	wasPresent bool
+	present    bool
+	// added is set if the last change was an Add, which is applied again on commit,
+	// e.g. to reset the expiry of an element of an ExpiringSet.
+	added bool
+	// replaced is set if a present element was added again with another value,
+	// so the present element is removed and element added on commit.
+	replaced bool
+}
+
+// setTx records the changes to a locked set. Elements are grouped by key; equal tells
+// elements with the same key apart and is nil if the key identifies an element.
+// Elements found equal by equal can still differ, e.g. in fields ignored by HashCode,
+// so Add compares them with the present element if the set is a valueSet.
+type setTx[T any, K comparable] struct {
+	set     txSet[T]
+	key     func(element T) K
+	equal   func(a, b T) bool
+	changes map[K][]*setTxChange[T]
+	order   []*setTxChange[T]
+	// delta is the change of the length, so the length of the set is only needed by Length.
+	delta int
+}
+
+// updateSet calls fn with a transaction holding the lock of s and applies the changes
+// if fn returns nil.
+func updateSet[T any, K comparable](
+	s txSet[T],
+	key func(element T) K,
+	equal func(a, b T) bool,
+	fn func(tx SetTx[T]) error,
+) error {
+	s.lock()
+	var events []SetEvent[T]
+	defer func() {
+		s.releaseLocked(events)
+	}()
+	removed, added, err := runSetTx(s, key, equal, fn)
+	if err != nil {
+		return err
+	}
+	events = commitSetTx(s, removed, added)
+	return nil
+}
+
+// runSetTx calls fn with a transaction on s, whose lock must be held.
+// It returns the elements to remove and to add to apply the transaction, or the error of fn.
+func runSetTx[T any, K comparable](
+	s txSet[T],
+	key func(element T) K,
+	equal func(a, b T) bool,
+	fn func(tx SetTx[T]) error,
+) ([]T, []T, error) {
+	tx := &setTx[T, K]{
+		set:     s,
+		key:     key,
+		equal:   equal,
+		changes: make(map[K][]*setTxChange[T]),
+	}
+	if err := fn(tx); err != nil {
+		return nil, nil, err
+	}
+	var removed, added []T
+	for _, change := range tx.order {
+		switch {
+		case change.wasPresent && !change.present:
+			removed = append(removed, change.element)
+		case change.replaced:
+			removed = append(removed, change.element)
+			added = append(added, change.element)
+		case change.added:
+			added = append(added, change.element)
+		}
+	}
+	return removed, added, nil
+}
+
+// commitSetTx applies the result of runSetTx to s and returns the events for the subscribers.
+// Elements are removed first, so a replacement never evicts from a full BoundedSet.
+func commitSetTx[T any](s txSet[T], removed []T, added []T) []SetEvent[T] {
+	return append(s.removeLocked(removed), s.addLocked(added)...)
+}
+
+func (t *setTx[T, K]) find(element T) *setTxChange[T] {
+	for _, change := range t.changes[t.key(element)] {
+		if t.equal == nil || t.equal(change.element, element) {
+			return change
+		}
+	}
+	return nil
+}
+
+func (t *setTx[T, K]) change(element T) *setTxChange[T] {
+	if change := t.find(element); change != nil {
+		return change
+	}
+	stored, present := element, false
+	if values, ok := t.set.(valueSet[T]); ok {
+		stored, present = values.getLocked(element)
+	} else {
+		present = t.set.containsLocked(element)
+	}
+	change := &setTxChange[T]{
+		element:    element,
+		stored:     stored,
+		wasPresent: present,
+		present:    present,
+	}
+	key := t.key(element)
+	t.changes[key] = append(t.changes[key], change)
+	t.order = append(t.order, change)
+	return change
+}
+
+func (t *setTx[T, K]) Add(elements ...T) {
+	for _, element := range elements {
+		change := t.change(element)
+		if !change.present {
+			t.delta++
+		}
+		change.element = element
+		change.present = true
+		change.added = true
+		change.replaced = change.wasPresent && !reflect.DeepEqual(change.stored, element)
+	}
+}
+
+func (t *setTx[T, K]) Remove(elements ...T) {
+	for _, element := range elements {
+		change := t.change(element)
+		if change.present {
+			t.delta--
+		}
+		change.present = false
+		change.added = false
+		change.replaced = false
+	}
+}
+
+func (t *setTx[T, K]) Contains(element T) bool {
+	if change := t.find(element); change != nil {
+		return change.present
+	}
+	return t.set.containsLocked(element)
+}
+
+func (t *setTx[T, K]) Length() int {
+	return t.set.lengthLocked() + t.delta
+}
+
+func (t *setTx[T, K]) Slice() []T {
+	var result []T
+	for _, element := range t.set.sliceLocked() {
+		change := t.find(element)
+		switch {
+		case change == nil:
+			result = append(result, element)
+		case change.present:
+			result = append(result, change.element)
+		}
+	}
+	for _, change := range t.order {
+		if change.present && !change.wasPresent {
+			result = append(result, change.element)
+		}
+	}
+	return result
+}
+
+// setUpdate is the Update method of a set.
+type setUpdate[T any] func(fn func(tx SetTx[T]) error) error
+
+// addIfAbsent inserts element with update and reports whether it was added.
+func addIfAbsent[T any](update setUpdate[T], element T) bool {
+	var result bool
+	_ = update(func(tx SetTx[T]) error {
+		result = !tx.Contains(element)
+		if result {
+			tx.Add(element)
+		}
+		return nil
+	})
+	return result
+}
+
+// removeIfPresent deletes element with update and reports whether it was removed.
+func removeIfPresent[T any](update setUpdate[T], element T) bool {
+	var result bool
+	_ = update(func(tx SetTx[T]) error {
+		result = tx.Contains(element)
+		tx.Remove(element)
+		return nil
+	})
+	return result
+}
+
+// replaceElement swaps oldElement for newElement with update if oldElement is present.
+func replaceElement[T any](update setUpdate[T], oldElement T, newElement T) bool {
+	var result bool
+	_ = update(func(tx SetTx[T]) error {
+		result = tx.Contains(oldElement)
+		if result {
+			tx.Remove(oldElement)
+			tx.Add(newElement)
+		}
+		return nil
+	})
+	return result
+}
+
+// compareAndSwap replaces the elements with update if they are exactly expected.
+// Elements present before and after are only replaced if their values differ.
+func compareAndSwap[T any](update setUpdate[T], expected []T, elements []T) bool {
+	err := update(func(tx SetTx[T]) error {
+		for _, element := range expected {
+			if !tx.Contains(element) {
+				return errSetTxMismatch
+			}
+		}
+		tx.Remove(expected...)
+		if tx.Length() > 0 {
+			return errSetTxMismatch
+		}
+		tx.Add(elements...)
+		return nil
+	})
+	return err == nil
+}
+
+// sameElement is the key of comparable elements within a transaction.
+func sameElement[T comparable](element T) T {
+	return element
+}
+
+// sameHashCode reports whether a and b have the same hash code.
+func sameHashCode[T HasHashCode](a, b T) bool {
+	return a.HashCode() == b.HashCode()
+}
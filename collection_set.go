@@ -7,12 +7,17 @@ package collection
 import (
 	"context"
 	"encoding/json"
+	"iter"
 	"sort"
 	"strings"
 	"sync"
-	"unsafe"
+	"sync/atomic"
+
+	"github.com/bborbe/errors"
 )
 
+//counterfeiter:generate -o mocks/collection-set.go --fake-name CollectionSet . Set
+
 // Set represents a thread-safe collection of unique elements.
 // This implementation uses a map for O(1) average-case lookups, additions, and deletions.
 type Set[T comparable] interface {
@@ -22,6 +27,20 @@ type Set[T comparable] interface {
 	// Remove deletes elements from the set.
 	// Multiple elements can be removed in a single call with only one mutex lock.
 	Remove(elements ...T)
+	// AddIfAbsent inserts element if it is not present and reports whether it was added.
+	AddIfAbsent(element T) bool
+	// RemoveIfPresent deletes element if it is present and reports whether it was removed.
+	RemoveIfPresent(element T) bool
+	// Replace removes oldElement and inserts newElement in one step if oldElement is present.
+	// It reports whether oldElement was present; otherwise the set is not changed.
+	Replace(oldElement T, newElement T) bool
+	// CompareAndSwap replaces the elements of the set with elements if the set contains
+	// exactly the elements of expected, and reports whether they were replaced.
+	CompareAndSwap(expected []T, elements []T) bool
+	// Update calls fn with a transaction holding the lock of the set. The changes made through
+	// the transaction are applied together if fn returns nil and discarded otherwise.
+	// fn must not call methods of the set, which would deadlock.
+	Update(fn func(tx SetTx[T]) error) error
 	// Contains reports whether an element is present in the set.
 	Contains(element T) bool
 	// ContainsAll reports whether all given elements are present in the set.
@@ -37,9 +56,32 @@ type Set[T comparable] interface {
 	Strings() []string
 	// String returns a human-readable string representation of the set.
 	String() string
-	// Each calls fn for each element in the set. Iteration stops on first error.
+	// Each calls fn for each element of a snapshot of the set. Iteration stops on first error.
+	// fn may access and change the set; changes are not reflected in the iteration.
 	// The order of iteration is arbitrary and not guaranteed to be consistent.
 	Each(ctx context.Context, fn func(ctx context.Context, value T) error) error
+	// EachLocked calls fn for each element while holding the lock of the set, so the set
+	// can't change during the iteration. fn must not call methods of the set, which would
+	// deadlock, and blocks all other callers while it runs.
+	// The order of iteration is arbitrary and not guaranteed to be consistent.
+	EachLocked(ctx context.Context, fn func(ctx context.Context, value T) error) error
+	// EachParallel calls fn for each element of a snapshot of the set using up to workers
+	// goroutines. The first error cancels the remaining calls and is returned.
+	EachParallel(
+		ctx context.Context,
+		workers int,
+		fn func(ctx context.Context, value T) error,
+	) error
+	// EachParallelCollectErrors calls fn for each element of a snapshot of the set using up to
+	// workers goroutines and returns the errors of all failed calls joined.
+	EachParallelCollectErrors(
+		ctx context.Context,
+		workers int,
+		fn func(ctx context.Context, value T) error,
+	) error
+	// All returns an iterator over a snapshot of the elements in arbitrary order.
+	// Changes to the set during iteration are not reflected.
+	All() iter.Seq[T]
 	// Clone returns a new Set containing all elements from the current set.
 	// The returned set is a shallow copy - modifications to it won't affect the original.
 	Clone() Set[T]
@@ -50,15 +92,46 @@ type Set[T comparable] interface {
 	// UnmarshalText parses comma-separated text into set elements.
 	// It implements encoding.TextUnmarshaler for automatic parsing with argument packages.
 	UnmarshalText(text []byte) error
-	// MarshalText converts set elements to comma-separated text.
+	// MarshalText converts set elements to comma-separated text in a deterministic order.
 	// It implements encoding.TextMarshaler for automatic serialization.
 	MarshalText() ([]byte, error)
 	// UnmarshalJSON deserializes a JSON array into set elements.
 	// It implements json.Unmarshaler for automatic JSON parsing.
 	UnmarshalJSON(data []byte) error
-	// MarshalJSON serializes set elements to a JSON array.
+	// MarshalJSON serializes set elements to a JSON array in a deterministic order.
 	// It implements json.Marshaler for automatic JSON serialization.
 	MarshalJSON() ([]byte, error)
+	// MarshalBinary encodes set elements into a compact, versioned binary format.
+	// It implements encoding.BinaryMarshaler.
+	MarshalBinary() ([]byte, error)
+	// UnmarshalBinary replaces the set elements with the elements decoded from data.
+	// It implements encoding.BinaryUnmarshaler.
+	UnmarshalBinary(data []byte) error
+	// GobEncode implements gob.GobEncoder using the binary format.
+	GobEncode() ([]byte, error)
+	// GobDecode implements gob.GobDecoder using the binary format.
+	// gob decodes into the existing set, so struct fields of this type must be initialized
+	// before decoding, e.g. with collection.NewSet[T]().
+	GobDecode(data []byte) error
+	// Union returns a new Set containing all elements of the current set and other.
+	Union(other Set[T]) Set[T]
+	// Intersection returns a new Set containing the elements present in both the current set and other.
+	Intersection(other Set[T]) Set[T]
+	// Difference returns a new Set containing the elements of the current set not present in other.
+	Difference(other Set[T]) Set[T]
+	// SymmetricDifference returns a new Set containing the elements present in exactly one of
+	// the current set and other.
+	SymmetricDifference(other Set[T]) Set[T]
+	// IsSubsetOf reports whether all elements of the current set are present in other.
+	IsSubsetOf(other Set[T]) bool
+	// IsSupersetOf reports whether all elements of other are present in the current set.
+	IsSupersetOf(other Set[T]) bool
+	// IsDisjoint reports whether the current set and other have no elements in common.
+	IsDisjoint(other Set[T]) bool
+	// Subscribe returns a channel that receives an event for every change of the set.
+	// Only elements that were actually added or removed are reported.
+	// The channel is closed once ctx is canceled.
+	Subscribe(ctx context.Context, options SubscribeOptions) <-chan SetEvent[T]
 }
 
 // NewSet creates a new thread-safe set for comparable types.
@@ -73,6 +146,27 @@ type Set[T comparable] interface {
 //	set := collection.NewSet(1, 2, 3)
 //	empty := collection.NewSet[int]()
 func NewSet[T comparable](elements ...T) Set[T] {
+	return newSet(elements...)
+}
+
+// NewSetWithCompare creates a new thread-safe set for comparable types that uses compare
+// to order elements in MarshalJSON and MarshalText. Without compare, sets of types based on
+// string, int, uint or float are written in natural order and all other sets ordered by the
+// encoding of their elements. compare is kept by Clone, Without, Union, Intersection,
+// Difference and SymmetricDifference.
+//
+// Example:
+//
+//	byName := func(a, b User) int { return cmp.Compare(a.Name, b.Name) }
+//	set := collection.NewSetWithCompare(byName, users...)
+func NewSetWithCompare[T comparable](compare func(a, b T) int, elements ...T) Set[T] {
+	s := newSet[T]()
+	s.compare = compare
+	s.Add(elements...)
+	return s
+}
+
+func newSet[T comparable](elements ...T) *set[T] {
 	s := &set[T]{
 		data: make(map[T]struct{}),
 	}
@@ -81,25 +175,144 @@ func NewSet[T comparable](elements ...T) Set[T] {
 }
 
 type set[T comparable] struct {
-	mux  sync.Mutex
-	data map[T]struct{}
+	id       atomic.Uint64
+	mux      sync.Mutex
+	data     map[T]struct{}
+	notifier setNotifier[T]
+	compare  func(a, b T) int
+}
+
+func (s *set[T]) lockOrder() uint64 {
+	return setLockID(&s.id)
+}
+
+func (s *set[T]) lock() {
+	s.mux.Lock()
+}
+
+func (s *set[T]) unlock() {
+	s.mux.Unlock()
+}
+
+// releaseLocked unlocks the set and delivers the events to the subscribers.
+func (s *set[T]) releaseLocked(events []SetEvent[T]) {
+	s.notifier.release(&s.mux, events...)
+}
+
+func (s *set[T]) containsLocked(element T) bool {
+	_, found := s.data[element]
+	return found
+}
+
+func (s *set[T]) lengthLocked() int {
+	return len(s.data)
+}
+
+func (s *set[T]) sliceLocked() []T {
+	result := make([]T, 0, len(s.data))
+	for k := range s.data {
+		result = append(result, k)
+	}
+	return result
 }
 
 func (s *set[T]) Add(elements ...T) {
 	s.mux.Lock()
-	defer s.mux.Unlock()
+	s.notifier.release(&s.mux, s.addLocked(elements)...)
+}
+
+// addLocked inserts elements and returns the event for the subscribers.
+func (s *set[T]) addLocked(elements []T) []SetEvent[T] {
+	track := s.notifier.hasSubscribers()
+	var added []T
 	for _, element := range elements {
+		if _, found := s.data[element]; found {
+			continue
+		}
 		s.data[element] = struct{}{}
+		if track {
+			added = append(added, element)
+		}
 	}
+	return []SetEvent[T]{{Type: SetEventAdded, Elements: added}}
 }
 
 func (s *set[T]) Remove(elements ...T) {
 	s.mux.Lock()
-	defer s.mux.Unlock()
+	s.notifier.release(&s.mux, s.removeLocked(elements)...)
+}
 
+// removeLocked deletes elements and returns the event for the subscribers.
+func (s *set[T]) removeLocked(elements []T) []SetEvent[T] {
+	track := s.notifier.hasSubscribers()
+	var removed []T
 	for _, element := range elements {
+		if _, found := s.data[element]; !found {
+			continue
+		}
 		delete(s.data, element)
+		if track {
+			removed = append(removed, element)
+		}
+	}
+	return []SetEvent[T]{{Type: SetEventRemoved, Elements: removed}}
+}
+
+// replace swaps the content of the set for elements and reports the changes to subscribers.
+func (s *set[T]) replace(elements []T) {
+	s.mux.Lock()
+	old := s.data
+	s.data = make(map[T]struct{}, len(elements))
+	for _, element := range elements {
+		s.data[element] = struct{}{}
+	}
+	if !s.notifier.hasSubscribers() {
+		s.mux.Unlock()
+		return
+	}
+	var added, removed []T
+	for element := range old {
+		if _, found := s.data[element]; !found {
+			removed = append(removed, element)
+		}
 	}
+	for element := range s.data {
+		if _, found := old[element]; !found {
+			added = append(added, element)
+		}
+	}
+	s.notifier.release(
+		&s.mux,
+		SetEvent[T]{Type: SetEventRemoved, Elements: removed},
+		SetEvent[T]{Type: SetEventAdded, Elements: added},
+	)
+}
+
+func (s *set[T]) Update(fn func(tx SetTx[T]) error) error {
+	return updateSet(s, sameElement[T], nil, fn)
+}
+
+func (s *set[T]) AddIfAbsent(element T) bool {
+	return addIfAbsent(s.Update, element)
+}
+
+func (s *set[T]) RemoveIfPresent(element T) bool {
+	return removeIfPresent(s.Update, element)
+}
+
+func (s *set[T]) Replace(oldElement T, newElement T) bool {
+	return replaceElement(s.Update, oldElement, newElement)
+}
+
+func (s *set[T]) CompareAndSwap(expected []T, elements []T) bool {
+	return compareAndSwap(s.Update, expected, elements)
+}
+
+// Subscribe returns a channel that receives an event for every change of the set.
+// Only elements that were actually added or removed are reported.
+// The channel is closed once ctx is canceled.
+func (s *set[T]) Subscribe(ctx context.Context, options SubscribeOptions) <-chan SetEvent[T] {
+	return s.notifier.subscribe(ctx, options)
 }
 
 func (s *set[T]) Contains(element T) bool {
@@ -174,9 +387,24 @@ func (s *set[T]) String() string {
 	return formatSetString("Set[", s.Strings())
 }
 
-// Each calls fn for each element in the set. Iteration stops on first error.
+// Each calls fn for each element of a snapshot of the set. Iteration stops on first error.
+// fn may access and change the set; changes are not reflected in the iteration.
 // The order of iteration is arbitrary and not guaranteed to be consistent.
-func (s *set[T]) Each(ctx context.Context, fn func(ctx context.Context, value T) error) error {
+func (s *set[T]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return Each(ctx, s.Slice(), fn)
+}
+
+// EachLocked calls fn for each element while holding the lock of the set, so the set
+// can't change during the iteration. fn must not call methods of the set, which would
+// deadlock, and blocks all other callers while it runs.
+// The order of iteration is arbitrary and not guaranteed to be consistent.
+func (s *set[T]) EachLocked(
+	ctx context.Context,
+	fn func(ctx context.Context, value T) error,
+) error {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 
@@ -200,7 +428,8 @@ func (s *set[T]) Clone() Set[T] {
 	defer s.mux.Unlock()
 
 	result := &set[T]{
-		data: make(map[T]struct{}, len(s.data)),
+		data:    make(map[T]struct{}, len(s.data)),
+		compare: s.compare,
 	}
 
 	for element := range s.data {
@@ -219,6 +448,95 @@ func (s *set[T]) Without(elements ...T) Set[T] {
 	return result
 }
 
+// EachParallel calls fn for each element of a snapshot of the set using up to workers
+// goroutines. The first error cancels the remaining calls and is returned.
+func (s *set[T]) EachParallel(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, false, fn)
+}
+
+// EachParallelCollectErrors calls fn for each element of a snapshot of the set using up to
+// workers goroutines and returns the errors of all failed calls joined.
+func (s *set[T]) EachParallelCollectErrors(
+	ctx context.Context,
+	workers int,
+	fn func(ctx context.Context, value T) error,
+) error {
+	return eachParallel(ctx, s.Slice(), workers, true, fn)
+}
+
+// All returns an iterator over a snapshot of the elements in arbitrary order.
+// Changes to the set during iteration are not reflected.
+func (s *set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range s.Slice() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// derive returns a new set containing elements that keeps the compare of the current set.
+func (s *set[T]) derive(elements []T) *set[T] {
+	result := newSet(elements...)
+	result.compare = s.compare
+	return result
+}
+
+// Union returns a new Set containing all elements of the current set and other.
+func (s *set[T]) Union(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return s.derive(unionLocked[T](sets))
+}
+
+// Intersection returns a new Set containing the elements present in both the current set and other.
+func (s *set[T]) Intersection(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return s.derive(intersectionLocked[T](sets))
+}
+
+// Difference returns a new Set containing the elements of the current set not present in other.
+func (s *set[T]) Difference(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return s.derive(differenceLocked[T](sets))
+}
+
+// SymmetricDifference returns a new Set containing the elements present in exactly one of
+// the current set and other.
+func (s *set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return s.derive(symmetricDifferenceLocked[T](sets))
+}
+
+// IsSubsetOf reports whether all elements of the current set are present in other.
+func (s *set[T]) IsSubsetOf(other Set[T]) bool {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isSubsetLocked[T](sets[0], sets[1])
+}
+
+// IsSupersetOf reports whether all elements of other are present in the current set.
+func (s *set[T]) IsSupersetOf(other Set[T]) bool {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isSubsetLocked[T](sets[1], sets[0])
+}
+
+// IsDisjoint reports whether the current set and other have no elements in common.
+func (s *set[T]) IsDisjoint(other Set[T]) bool {
+	sets := toLockedSets([]Set[T]{s, other})
+	defer lockOrdered(sets...)()
+	return isDisjointLocked[T](sets[0], sets[1])
+}
+
 // ParseSetFromStrings converts a slice of strings into a Set with string-based type.
 // T must be string or a type based on string (using ~string constraint).
 func ParseSetFromStrings[T ~string](values []string) Set[T] {
@@ -245,51 +563,61 @@ func ParseSetFromString[T ~string](value string) Set[T] {
 	return ParseSetFromStrings[T](trimmed)
 }
 
+// ParseSetFunc parses a comma-separated string into a Set using parse for each element.
+// Whitespace around elements is trimmed and empty elements are skipped.
+// It returns an error if parse fails for any element.
+//
+// Example:
+//
+//	ports, err := collection.ParseSetFunc("80, 443", strconv.Atoi)
+func ParseSetFunc[T comparable](value string, parse func(value string) (T, error)) (Set[T], error) {
+	parts := splitText(value)
+	elements := make([]T, 0, len(parts))
+	for _, part := range parts {
+		element, err := parse(part)
+		if err != nil {
+			return nil, errors.Wrapf(context.Background(), err, "parse %q failed", part)
+		}
+		elements = append(elements, element)
+	}
+	return NewSet(elements...), nil
+}
+
 // MarshalText implements encoding.TextMarshaler for Set.
+// Elements are written in the order of the compare function of the set, in natural order
+// for types based on string, int, uint or float, or sorted by their string representation.
 func (s *set[S]) MarshalText() ([]byte, error) {
-	return []byte(strings.Join(s.Strings(), ",")), nil
+	return marshalSortedText(s.Slice(), s.compare), nil
 }
 
-// UnmarshalText implements encoding.TextUnmarshaler for set with string element type.
-// This allows Set[string] to be automatically parsed from comma-separated strings
+// UnmarshalText implements encoding.TextUnmarshaler for Set.
+// This allows Set[T] to be automatically parsed from comma-separated strings
 // when used with github.com/bborbe/argument.
+// Each part is converted using encoding.TextUnmarshaler of the element, time.ParseDuration
+// for time.Duration or directly for types based on string, bool, int, uint and float.
+// The set is left unchanged if a part can't be parsed.
 func (s *set[S]) UnmarshalText(text []byte) error {
-	value := string(text)
-	parts := strings.FieldsFunc(value, func(r rune) bool {
-		return r == ','
-	})
-
-	// Clear existing data
-	s.mux.Lock()
-	defer s.mux.Unlock()
-	s.data = make(map[S]struct{})
-
-	// Add trimmed parts
+	parts := splitText(string(text))
+	elements := make([]S, 0, len(parts))
 	for _, part := range parts {
-		if trimmed := strings.TrimSpace(part); trimmed != "" {
-			// Convert string to S type using unsafe pointer conversion
-			// This works for any type S where the underlying type is string
-			// The conversion is safe because both string and ~string types have identical memory layout
-			element := *(*S)(unsafe.Pointer(&trimmed)) //#nosec G103 -- Safe conversion between string-based types
-			s.data[element] = struct{}{}
+		element, err := parseTextElement[S](part)
+		if err != nil {
+			return err
 		}
+		elements = append(elements, element)
 	}
 
+	s.replace(elements)
 	return nil
 }
 
 // MarshalJSON implements json.Marshaler for Set.
 // It serializes the set as a JSON array of elements, supporting primitives,
-// complex types, maps, and objects.
+// complex types, maps, and objects. Elements are written in the order of the compare
+// function of the set, in natural order for types based on string, int, uint or float,
+// or sorted by their JSON encoding, so equal sets always produce the same output.
 func (s *set[T]) MarshalJSON() ([]byte, error) {
-	s.mux.Lock()
-	defer s.mux.Unlock()
-
-	elements := make([]T, 0, len(s.data))
-	for k := range s.data {
-		elements = append(elements, k)
-	}
-	return json.Marshal(elements)
+	return marshalSortedJSON(s.Slice(), s.compare)
 }
 
 // UnmarshalJSON implements json.Unmarshaler for Set.
@@ -301,13 +629,33 @@ func (s *set[T]) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
-	s.mux.Lock()
-	defer s.mux.Unlock()
-	s.data = make(map[T]struct{})
+	s.replace(elements)
+	return nil
+}
 
-	for _, element := range elements {
-		s.data[element] = struct{}{}
-	}
+// MarshalBinary implements encoding.BinaryMarshaler for Set.
+// Elements are written in arbitrary order.
+func (s *set[T]) MarshalBinary() ([]byte, error) {
+	return marshalSetBinary(s.Slice())
+}
 
+// UnmarshalBinary implements encoding.BinaryUnmarshaler for Set.
+// The set is left unchanged if data is invalid.
+func (s *set[T]) UnmarshalBinary(data []byte) error {
+	elements, err := unmarshalSetBinary[T](data)
+	if err != nil {
+		return err
+	}
+	s.replace(elements)
 	return nil
 }
+
+// GobEncode implements gob.GobEncoder for Set using the binary format.
+func (s *set[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder for Set using the binary format.
+func (s *set[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("Set algebra", func() {
+	Context("Set", func() {
+		var a, b collection.Set[int]
+		BeforeEach(func() {
+			a = collection.NewSet(1, 2, 3)
+			b = collection.NewSet(3, 4)
+		})
+		It("returns union", func() {
+			Expect(a.Union(b).Slice()).To(ConsistOf(1, 2, 3, 4))
+		})
+		It("returns intersection", func() {
+			Expect(a.Intersection(b).Slice()).To(ConsistOf(3))
+		})
+		It("returns difference", func() {
+			Expect(a.Difference(b).Slice()).To(ConsistOf(1, 2))
+			Expect(b.Difference(a).Slice()).To(ConsistOf(4))
+		})
+		It("returns symmetric difference", func() {
+			Expect(a.SymmetricDifference(b).Slice()).To(ConsistOf(1, 2, 4))
+		})
+		It("does not modify inputs", func() {
+			a.Union(b)
+			a.Difference(b)
+			Expect(a.Slice()).To(ConsistOf(1, 2, 3))
+			Expect(b.Slice()).To(ConsistOf(3, 4))
+		})
+		It("reports subset and superset", func() {
+			sub := collection.NewSet(1, 2)
+			Expect(sub.IsSubsetOf(a)).To(BeTrue())
+			Expect(a.IsSubsetOf(sub)).To(BeFalse())
+			Expect(a.IsSupersetOf(sub)).To(BeTrue())
+			Expect(sub.IsSupersetOf(a)).To(BeFalse())
+			Expect(a.IsSubsetOf(a)).To(BeTrue())
+			Expect(collection.NewSet[int]().IsSubsetOf(a)).To(BeTrue())
+		})
+		It("reports disjoint", func() {
+			Expect(a.IsDisjoint(b)).To(BeFalse())
+			Expect(a.IsDisjoint(collection.NewSet(7, 8))).To(BeTrue())
+			Expect(a.IsDisjoint(collection.NewSet[int]())).To(BeTrue())
+		})
+		It("combines a set with itself", func() {
+			Expect(a.Union(a).Slice()).To(ConsistOf(1, 2, 3))
+			Expect(a.Intersection(a).Slice()).To(ConsistOf(1, 2, 3))
+			Expect(a.Difference(a).Length()).To(Equal(0))
+		})
+		It("does not deadlock when combining the same sets concurrently", func() {
+			var wg sync.WaitGroup
+			for i := 0; i < 100; i++ {
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					a.Union(b)
+					a.IsSubsetOf(b)
+				}()
+				go func() {
+					defer wg.Done()
+					b.Union(a)
+					b.IsDisjoint(a)
+				}()
+			}
+			wg.Wait()
+		})
+	})
+	Context("Set free functions", func() {
+		var a, b, c collection.Set[string]
+		BeforeEach(func() {
+			a = collection.NewSet("a", "b", "c")
+			b = collection.NewSet("b", "c", "d")
+			c = collection.NewSet("c", "e")
+		})
+		It("returns union of all sets", func() {
+			Expect(collection.SetUnion(a, b, c).Slice()).To(ConsistOf("a", "b", "c", "d", "e"))
+		})
+		It("returns intersection of all sets", func() {
+			Expect(collection.SetIntersection(a, b, c).Slice()).To(ConsistOf("c"))
+		})
+		It("returns difference of first set and all others", func() {
+			Expect(collection.SetDifference(a, b, c).Slice()).To(ConsistOf("a"))
+		})
+		It("returns elements present in an odd number of sets", func() {
+			Expect(collection.SetSymmetricDifference(a, b, c).Slice()).To(ConsistOf("a", "c", "d", "e"))
+		})
+		It("returns empty sets without input", func() {
+			Expect(collection.SetUnion[string]().Length()).To(Equal(0))
+			Expect(collection.SetIntersection[string]().Length()).To(Equal(0))
+			Expect(collection.SetDifference[string]().Length()).To(Equal(0))
+			Expect(collection.SetSymmetricDifference[string]().Length()).To(Equal(0))
+		})
+	})
+	Context("SetHashCode", func() {
+		var a, b collection.SetHashCode[User]
+		var alice, bob, carl User
+		BeforeEach(func() {
+			alice = User{Firstname: "Alice"}
+			bob = User{Firstname: "Bob"}
+			carl = User{Firstname: "Carl"}
+			a = collection.NewSetHashCode(alice, bob)
+			b = collection.NewSetHashCode(bob, carl)
+		})
+		It("returns union", func() {
+			Expect(a.Union(b).Slice()).To(ConsistOf(alice, bob, carl))
+		})
+		It("returns intersection", func() {
+			Expect(a.Intersection(b).Slice()).To(ConsistOf(bob))
+		})
+		It("returns difference", func() {
+			Expect(a.Difference(b).Slice()).To(ConsistOf(alice))
+		})
+		It("returns symmetric difference", func() {
+			Expect(a.SymmetricDifference(b).Slice()).To(ConsistOf(alice, carl))
+		})
+		It("reports subset, superset and disjoint", func() {
+			sub := collection.NewSetHashCode(bob)
+			Expect(sub.IsSubsetOf(a)).To(BeTrue())
+			Expect(a.IsSupersetOf(sub)).To(BeTrue())
+			Expect(a.IsSubsetOf(b)).To(BeFalse())
+			Expect(a.IsDisjoint(b)).To(BeFalse())
+			Expect(a.IsDisjoint(collection.NewSetHashCode(carl))).To(BeTrue())
+		})
+		It("supports free functions with multiple sets", func() {
+			c := collection.NewSetHashCode(carl)
+			Expect(collection.SetHashCodeUnion(a, b, c).Length()).To(Equal(3))
+			Expect(collection.SetHashCodeIntersection(a, b, c).Length()).To(Equal(0))
+			Expect(collection.SetHashCodeDifference(a, b, c).Slice()).To(ConsistOf(alice))
+			Expect(collection.SetHashCodeSymmetricDifference(a, b, c).Slice()).To(ConsistOf(alice))
+		})
+	})
+	Context("SetEqual", func() {
+		var a, b collection.SetEqual[User]
+		var alice, bob, carl, dave User
+		BeforeEach(func() {
+			alice = User{Firstname: "Alice"}
+			bob = User{Firstname: "Bob"}
+			carl = User{Firstname: "Carl"}
+			dave = User{Firstname: "Dave"}
+			a = collection.NewSetEqual(carl, alice, bob)
+			b = collection.NewSetEqual(dave, bob, carl)
+		})
+		It("returns union in insertion order", func() {
+			Expect(a.Union(b).Slice()).To(Equal([]User{carl, alice, bob, dave}))
+		})
+		It("returns intersection in insertion order of the current set", func() {
+			Expect(a.Intersection(b).Slice()).To(Equal([]User{carl, bob}))
+		})
+		It("returns difference", func() {
+			Expect(a.Difference(b).Slice()).To(Equal([]User{alice}))
+		})
+		It("returns symmetric difference", func() {
+			Expect(a.SymmetricDifference(b).Slice()).To(Equal([]User{alice, dave}))
+		})
+		It("reports subset, superset and disjoint", func() {
+			sub := collection.NewSetEqual(bob, alice)
+			Expect(sub.IsSubsetOf(a)).To(BeTrue())
+			Expect(a.IsSupersetOf(sub)).To(BeTrue())
+			Expect(sub.IsSubsetOf(b)).To(BeFalse())
+			Expect(sub.IsDisjoint(collection.NewSetEqual(dave))).To(BeTrue())
+		})
+		It("supports free functions with multiple sets", func() {
+			c := collection.NewSetEqual(alice, dave)
+			Expect(collection.SetEqualUnion(a, b, c).Length()).To(Equal(4))
+			Expect(collection.SetEqualIntersection(a, b, c).Length()).To(Equal(0))
+			Expect(collection.SetEqualDifference(b, a, c).Length()).To(Equal(0))
+			Expect(collection.SetEqualSymmetricDifference(a, b, c).Slice()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,156 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+type gobCache struct {
+	Name   string
+	Tags   collection.Set[string]
+	Ports  collection.Set[int]
+	Users  collection.SetHashCode[User]
+	Admins collection.SetEqual[User]
+	Nested gobCacheEntry
+}
+
+type gobCacheEntry struct {
+	Accounts collection.SetHashCode[account]
+	Count    int
+}
+
+func newGobCache() gobCache {
+	return gobCache{
+		Tags:   collection.NewSet[string](),
+		Ports:  collection.NewSet[int](),
+		Users:  collection.NewSetHashCode[User](),
+		Admins: collection.NewSetEqual[User](),
+		Nested: gobCacheEntry{
+			Accounts: collection.NewSetHashCode[account](),
+		},
+	}
+}
+
+func roundTripBinary(from encoding.BinaryMarshaler, to encoding.BinaryUnmarshaler) {
+	data, err := from.MarshalBinary()
+	Expect(err).NotTo(HaveOccurred())
+	Expect(to.UnmarshalBinary(data)).To(Succeed())
+}
+
+var _ = Describe("Set binary", func() {
+	DescribeTable("round-trips Set elements",
+		func(from any, to any) {
+			roundTripBinary(from.(encoding.BinaryMarshaler), to.(encoding.BinaryUnmarshaler))
+			Expect(json.Marshal(to)).To(Equal(must(json.Marshal(from))))
+		},
+		Entry("string", collection.NewSet("a", "", "ü"), collection.NewSet[string]()),
+		Entry("int", collection.NewSet(-300, 0, 1<<40), collection.NewSet[int]()),
+		Entry("uint8", collection.NewSet[uint8](0, 255), collection.NewSet[uint8]()),
+		Entry("float64", collection.NewSet(1.5, -2.25), collection.NewSet[float64]()),
+		Entry("bool", collection.NewSet(true, false), collection.NewSet[bool]()),
+		Entry(
+			"time.Duration",
+			collection.NewSet(time.Second, time.Hour),
+			collection.NewSet[time.Duration](),
+		),
+		Entry(
+			"time.Time via encoding.BinaryMarshaler",
+			collection.NewSet(time.Date(2026, 1, 2, 3, 4, 5, 6, time.UTC)),
+			collection.NewSet[time.Time](),
+		),
+		Entry(
+			"struct via gob",
+			collection.NewSet(User{Firstname: "Alice", Age: 25}, User{Firstname: "Bob"}),
+			collection.NewSet[User](),
+		),
+	)
+	It("writes a versioned header", func() {
+		data, err := collection.NewSet(1, 2, 3).MarshalBinary()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(data[0]).To(Equal(byte(1)))
+		Expect(len(data)).To(BeNumerically("<", len(must(json.Marshal([]int{1, 2, 3})))+3))
+	})
+	It("replaces existing elements", func() {
+		set := collection.NewSet("old")
+		roundTripBinary(collection.NewSet("new"), set)
+		Expect(set.Slice()).To(ConsistOf("new"))
+	})
+	DescribeTable("rejects invalid data and keeps the set",
+		func(data []byte) {
+			set := collection.NewSet(1)
+			Expect(set.UnmarshalBinary(data)).NotTo(Succeed())
+			Expect(set.Slice()).To(ConsistOf(1))
+		},
+		Entry("empty", []byte{}),
+		Entry("unknown version", []byte{99, 1, 0}),
+		Entry("unknown encoding", []byte{1, 99, 0}),
+		Entry("count exceeds data", []byte{1, 1, 5, 1, 2}),
+		Entry("truncated element", []byte{1, 1, 1, 4, 2}),
+		Entry("trailing bytes", []byte{1, 1, 1, 1, 2, 7}),
+	)
+	It("round-trips SetHashCode", func() {
+		from := collection.NewSetHashCode(User{Firstname: "Alice"}, User{Firstname: "Bob"})
+		to := collection.NewSetHashCode[User]()
+		roundTripBinary(from, to)
+		Expect(to.Slice()).To(ConsistOf(from.Slice()))
+	})
+	It("round-trips SetEqual in insertion order", func() {
+		from := collection.NewSetEqual(User{Firstname: "Carl"}, User{Firstname: "Alice"})
+		to := collection.NewSetEqual[User]()
+		roundTripBinary(from, to)
+		Expect(to.Slice()).To(Equal(from.Slice()))
+	})
+	It("round-trips LinkedSet in insertion order", func() {
+		from := collection.NewLinkedSet(3, 1, 2)
+		to := collection.NewLinkedSet[int]()
+		roundTripBinary(from, to)
+		Expect(to.Slice()).To(Equal([]int{3, 1, 2}))
+	})
+	It("gob-encodes sets nested in structs", func() {
+		alice := User{Firstname: "Alice", Age: 25}
+		bob := User{Firstname: "Bob", Age: 30}
+		original := gobCache{
+			Name:   "cache",
+			Tags:   collection.NewSet("a", "b"),
+			Ports:  collection.NewSet(80, 443),
+			Users:  collection.NewSetHashCode(alice, bob),
+			Admins: collection.NewSetEqual(bob, alice),
+			Nested: gobCacheEntry{
+				Accounts: collection.NewSetHashCode(account{ID: "1", Name: "root"}),
+				Count:    7,
+			},
+		}
+		var buf bytes.Buffer
+		Expect(gob.NewEncoder(&buf).Encode(original)).To(Succeed())
+
+		decoded := newGobCache()
+		Expect(gob.NewDecoder(&buf).Decode(&decoded)).To(Succeed())
+		Expect(decoded.Name).To(Equal("cache"))
+		Expect(decoded.Tags.Slice()).To(ConsistOf("a", "b"))
+		Expect(decoded.Ports.Slice()).To(ConsistOf(80, 443))
+		Expect(decoded.Users.Slice()).To(ConsistOf(alice, bob))
+		Expect(decoded.Admins.Slice()).To(Equal([]User{bob, alice}))
+		Expect(decoded.Nested.Accounts.Slice()).To(ConsistOf(account{ID: "1", Name: "root"}))
+		Expect(decoded.Nested.Count).To(Equal(7))
+
+		decoded.Tags.Add("c")
+		Expect(decoded.Tags.Length()).To(Equal(3))
+	})
+})
+
+func must[T any](value T, err error) T {
+	Expect(err).NotTo(HaveOccurred())
+	return value
+}
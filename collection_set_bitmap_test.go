@@ -0,0 +1,151 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"encoding/json"
+	"math/rand"
+	"sort"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("BitmapSet", func() {
+	It("keeps elements in ascending order", func() {
+		set := collection.NewBitmapSet(5, -3, 1<<40, 0, -1<<40, 5)
+		Expect(set.Slice()).To(Equal([]int{-1 << 40, -3, 0, 5, 1 << 40}))
+		Expect(set.Length()).To(Equal(5))
+		Expect(set.Contains(-3)).To(BeTrue())
+		Expect(set.Contains(3)).To(BeFalse())
+		Expect(set.String()).To(Equal("BitmapSet[-1099511627776, -3, 0, 5, 1099511627776]"))
+	})
+	It("removes elements", func() {
+		set := collection.NewBitmapSet[uint32](1, 2, 70000)
+		set.Remove(2, 70000, 3)
+		Expect(set.Slice()).To(Equal([]uint32{1}))
+		Expect(set.ContainsAll(1, 2)).To(BeFalse())
+		Expect(set.ContainsAny(2, 1)).To(BeTrue())
+	})
+	It("handles dense containers", func() {
+		set := collection.NewBitmapSet[uint16]()
+		for i := 0; i < 10000; i++ {
+			set.Add(uint16(i * 2))
+		}
+		Expect(set.Length()).To(Equal(10000))
+		Expect(set.Contains(19998)).To(BeTrue())
+		Expect(set.Contains(19999)).To(BeFalse())
+		for i := 0; i < 9990; i++ {
+			set.Remove(uint16(i * 2))
+		}
+		Expect(set.Slice()).To(Equal([]uint16{
+			19980, 19982, 19984, 19986, 19988, 19990, 19992, 19994, 19996, 19998,
+		}))
+	})
+	It("combines sets like Set", func() {
+		r := rand.New(rand.NewSource(1)) // #nosec G404 -- deterministic test data
+		a := collection.NewBitmapSet[int]()
+		b := collection.NewBitmapSet[int]()
+		plainA := collection.NewSet[int]()
+		plainB := collection.NewSet[int]()
+		for i := 0; i < 20000; i++ {
+			value := r.Intn(300000) - 100000
+			if r.Intn(2) == 0 {
+				a.Add(value)
+				plainA.Add(value)
+			} else {
+				b.Add(value)
+				plainB.Add(value)
+			}
+		}
+		sorted := func(set collection.Set[int]) []int {
+			result := set.Slice()
+			sort.Ints(result)
+			return result
+		}
+		Expect(a.And(b).Slice()).To(Equal(sorted(plainA.Intersection(plainB))))
+		Expect(a.Or(b).Slice()).To(Equal(sorted(plainA.Union(plainB))))
+		Expect(a.AndNot(b).Slice()).To(Equal(sorted(plainA.Difference(plainB))))
+		Expect(a.Xor(b).Slice()).To(Equal(sorted(plainA.SymmetricDifference(plainB))))
+	})
+	It("supports set algebra with other sets", func() {
+		set := collection.NewBitmapSet(1, 2, 3)
+		other := collection.NewSet(3, 4)
+		Expect(set.Union(other).Slice()).To(Equal([]int{1, 2, 3, 4}))
+		Expect(set.Intersection(other).Slice()).To(Equal([]int{3}))
+		Expect(set.IsSubsetOf(collection.NewSet(1, 2, 3, 4))).To(BeTrue())
+		Expect(set.IsSupersetOf(collection.NewSet(1, 2))).To(BeTrue())
+		Expect(set.IsDisjoint(collection.NewSet(4, 5))).To(BeTrue())
+		Expect(set.IsSubsetOf(set)).To(BeTrue())
+	})
+	It("round-trips json and text", func() {
+		set := collection.NewBitmapSet(3, -1, 2)
+		data, err := json.Marshal(set)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`[-1,2,3]`))
+
+		decoded := collection.NewBitmapSet(7)
+		Expect(json.Unmarshal(data, decoded)).To(Succeed())
+		Expect(decoded.Slice()).To(Equal([]int{-1, 2, 3}))
+
+		text, err := set.MarshalText()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(text)).To(Equal("-1,2,3"))
+		Expect(decoded.UnmarshalText([]byte("4,1"))).To(Succeed())
+		Expect(decoded.Slice()).To(Equal([]int{1, 4}))
+	})
+	It("round-trips binary and gob", func() {
+		set := collection.NewBitmapSet[int64](-5, 0, 1<<33)
+		for i := int64(0); i < 5000; i++ {
+			set.Add(100000 + i)
+		}
+		data, err := set.MarshalBinary()
+		Expect(err).NotTo(HaveOccurred())
+		decoded := collection.NewBitmapSet[int64]()
+		Expect(decoded.UnmarshalBinary(data)).To(Succeed())
+		Expect(decoded.Slice()).To(Equal(set.Slice()))
+
+		var buf bytes.Buffer
+		Expect(gob.NewEncoder(&buf).Encode(set)).To(Succeed())
+		gobDecoded := collection.NewBitmapSet[int64]()
+		Expect(gob.NewDecoder(&buf).Decode(gobDecoded)).To(Succeed())
+		Expect(gobDecoded.Slice()).To(Equal(set.Slice()))
+	})
+	It("rejects invalid binary data", func() {
+		set := collection.NewBitmapSet[int8](1)
+		Expect(set.UnmarshalBinary([]byte{0})).NotTo(Succeed())
+
+		data, err := collection.NewBitmapSet(1000).MarshalBinary()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(set.UnmarshalBinary(data)).NotTo(Succeed())
+		Expect(set.UnmarshalBinary(data[:len(data)-1])).NotTo(Succeed())
+		Expect(set.Slice()).To(Equal([]int8{1}))
+	})
+	It("reports changes to subscribers", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		set := collection.NewBitmapSet(1, 2)
+		events := set.Subscribe(ctx, collection.SubscribeOptions{BufferSize: 10})
+		set.Add(2, 3)
+		Expect(<-events).To(Equal(collection.SetEvent[int]{
+			Type:     collection.SetEventAdded,
+			Elements: []int{3},
+		}))
+		Expect(json.Unmarshal([]byte(`[3,4]`), set)).To(Succeed())
+		Expect(<-events).To(Equal(collection.SetEvent[int]{
+			Type:     collection.SetEventRemoved,
+			Elements: []int{1, 2},
+		}))
+		Expect(<-events).To(Equal(collection.SetEvent[int]{
+			Type:     collection.SetEventAdded,
+			Elements: []int{4},
+		}))
+	})
+})
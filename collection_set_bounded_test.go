@@ -0,0 +1,77 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("BoundedSet", func() {
+	It("evicts the least recently used element on Add", func() {
+		set := collection.NewBoundedSet(2, "a", "b")
+		set.Add("c")
+		Expect(set.Slice()).To(Equal([]string{"b", "c"}))
+		Expect(set.Length()).To(Equal(2))
+		Expect(set.Capacity()).To(Equal(2))
+	})
+	It("marks elements as used on Add and Contains", func() {
+		set := collection.NewBoundedSet(2, "a", "b")
+		Expect(set.Contains("a")).To(BeTrue())
+		set.Add("c")
+		Expect(set.Slice()).To(Equal([]string{"a", "c"}))
+		set.Add("a")
+		set.Add("d")
+		Expect(set.Slice()).To(Equal([]string{"a", "d"}))
+	})
+	It("keeps the last initial elements", func() {
+		set := collection.NewBoundedSet(2, 1, 2, 3)
+		Expect(set.Slice()).To(Equal([]int{2, 3}))
+	})
+	It("reports evicted elements to subscribers", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		set := collection.NewBoundedSet(1, "a")
+		events := set.Subscribe(ctx, collection.SubscribeOptions{BufferSize: 10})
+		set.Add("b")
+		Expect(<-events).To(Equal(collection.SetEvent[string]{
+			Type:     collection.SetEventAdded,
+			Elements: []string{"b"},
+		}))
+		Expect(<-events).To(Equal(collection.SetEvent[string]{
+			Type:     collection.SetEventRemoved,
+			Elements: []string{"a"},
+		}))
+	})
+	It("clones capacity and recency", func() {
+		set := collection.NewBoundedSet(2, "a", "b")
+		clone := set.Clone()
+		clone.Add("c")
+		Expect(clone.Slice()).To(Equal([]string{"b", "c"}))
+		Expect(set.Slice()).To(Equal([]string{"a", "b"}))
+	})
+	It("returns unbounded sets from set algebra", func() {
+		set := collection.NewBoundedSet(2, "a", "b")
+		union := set.Union(collection.NewSet("c"))
+		Expect(union.Slice()).To(Equal([]string{"a", "b", "c"}))
+	})
+	It("round-trips json in recency order", func() {
+		set := collection.NewBoundedSet(3, "a", "b", "c")
+		set.Contains("a")
+		data, err := json.Marshal(set)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`["b","c","a"]`))
+
+		decoded := collection.NewBoundedSet[string](2)
+		Expect(json.Unmarshal(data, decoded)).To(Succeed())
+		Expect(decoded.Slice()).To(Equal([]string{"c", "a"}))
+		Expect(decoded.String()).To(Equal("BoundedSet[c, a]"))
+	})
+})
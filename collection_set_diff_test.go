@@ -0,0 +1,181 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+type account struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func (a account) HashCode() string {
+	return a.ID
+}
+
+var _ = Describe("Diff", func() {
+	It("returns added and removed elements", func() {
+		diff := collection.Diff(collection.NewSet(1, 2, 3), collection.NewSet(2, 3, 4, 5))
+		Expect(diff.Added).To(ConsistOf(4, 5))
+		Expect(diff.Removed).To(ConsistOf(1))
+		Expect(diff.IsEmpty()).To(BeFalse())
+	})
+	It("returns an empty diff for equal sets", func() {
+		diff := collection.Diff(collection.NewSet(1, 2), collection.NewSet(2, 1))
+		Expect(diff.IsEmpty()).To(BeTrue())
+	})
+	It("applies a diff", func() {
+		from := collection.NewSet("a", "b")
+		to := collection.NewSet("b", "c")
+		diff := collection.Diff(from, to)
+		collection.Apply(from, diff)
+		Expect(from.Slice()).To(ConsistOf("b", "c"))
+		collection.Apply(from, diff)
+		Expect(from.Slice()).To(ConsistOf("b", "c"))
+	})
+	It("replays a diff decoded from json", func() {
+		diff := collection.Diff(collection.NewSet("a", "b"), collection.NewSet("b", "c"))
+		data, err := json.Marshal(diff)
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(Equal(`{"added":["c"],"removed":["a"]}`))
+		var decoded collection.SetDiff[string]
+		Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+		set := collection.NewSet("a", "b")
+		collection.Apply(set, decoded)
+		Expect(set.Slice()).To(ConsistOf("b", "c"))
+	})
+	It("encodes equal diffs to the same json", func() {
+		from := collection.NewSet[int]()
+		to := collection.NewSet[int]()
+		for i := 0; i < 100; i++ {
+			from.Add(i)
+			to.Add(i + 50)
+		}
+		expected, err := json.Marshal(collection.Diff(from, to))
+		Expect(err).To(BeNil())
+		for i := 0; i < 10; i++ {
+			data, err := json.Marshal(collection.Diff(from, to))
+			Expect(err).To(BeNil())
+			Expect(data).To(Equal(expected))
+		}
+		Expect(collection.Diff(from, to).Removed[:3]).To(Equal([]int{0, 1, 2}))
+	})
+	It("sorts elements without natural order by their json encoding", func() {
+		from := collection.NewSet[account]()
+		to := collection.NewSet(account{ID: "2"}, account{ID: "1"}, account{ID: "3"})
+		diff := collection.Diff(from, to)
+		Expect(diff.Added).To(Equal([]account{{ID: "1"}, {ID: "2"}, {ID: "3"}}))
+	})
+	It("omits empty changes in json", func() {
+		data, err := json.Marshal(collection.SetDiff[string]{})
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(Equal(`{}`))
+	})
+})
+
+var _ = Describe("DiffSetHashCode", func() {
+	var from, to collection.SetHashCode[account]
+	BeforeEach(func() {
+		from = collection.NewSetHashCode(
+			account{ID: "1", Name: "alice"},
+			account{ID: "2", Name: "bob"},
+			account{ID: "3", Name: "carl"},
+		)
+		to = collection.NewSetHashCode(
+			account{ID: "2", Name: "bobby"},
+			account{ID: "3", Name: "carl"},
+			account{ID: "4", Name: "dave"},
+		)
+	})
+	It("returns added, removed and changed elements", func() {
+		diff := collection.DiffSetHashCode(from, to)
+		Expect(diff.Added).To(ConsistOf(account{ID: "4", Name: "dave"}))
+		Expect(diff.Removed).To(ConsistOf(account{ID: "1", Name: "alice"}))
+		Expect(diff.Changed).To(ConsistOf(account{ID: "2", Name: "bobby"}))
+	})
+	It("encodes equal diffs to the same json", func() {
+		for i := 5; i < 100; i++ {
+			to.Add(account{ID: strconv.Itoa(i), Name: "new"})
+		}
+		expected, err := json.Marshal(collection.DiffSetHashCode(from, to))
+		Expect(err).To(BeNil())
+		for i := 0; i < 10; i++ {
+			data, err := json.Marshal(collection.DiffSetHashCode(from, to))
+			Expect(err).To(BeNil())
+			Expect(data).To(Equal(expected))
+		}
+	})
+	It("compares values with Equal if available", func() {
+		diff := collection.DiffSetHashCode(
+			collection.NewSetHashCode(User{Firstname: "Alice"}),
+			collection.NewSetHashCode(User{Firstname: "Alice"}),
+		)
+		Expect(diff.IsEmpty()).To(BeTrue())
+	})
+	It("applies a diff decoded from json", func() {
+		data, err := json.Marshal(collection.DiffSetHashCode(from, to))
+		Expect(err).To(BeNil())
+		var diff collection.SetHashCodeDiff[account]
+		Expect(json.Unmarshal(data, &diff)).To(Succeed())
+		collection.ApplySetHashCode(from, diff)
+		Expect(from.Slice()).To(ConsistOf(to.Slice()))
+		Expect(collection.DiffSetHashCode(from, to).IsEmpty()).To(BeTrue())
+	})
+	It("applies a diff in one change", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		events := from.Subscribe(ctx, collection.SubscribeOptions{BufferSize: 10})
+		collection.ApplySetHashCode(from, collection.DiffSetHashCode(from, to))
+		Expect(<-events).To(Equal(collection.SetEvent[account]{
+			Type: collection.SetEventRemoved,
+			Elements: []account{
+				{ID: "1", Name: "alice"},
+				{ID: "2", Name: "bob"},
+			},
+		}))
+		Expect(<-events).To(Equal(collection.SetEvent[account]{
+			Type: collection.SetEventAdded,
+			Elements: []account{
+				{ID: "4", Name: "dave"},
+				{ID: "2", Name: "bobby"},
+			},
+		}))
+		collection.ApplySetHashCode(from, collection.DiffSetHashCode(from, to))
+		Consistently(events).ShouldNot(Receive())
+	})
+})
+
+var _ = Describe("DiffSetEqual", func() {
+	var alice, bob, carl User
+	BeforeEach(func() {
+		alice = User{Firstname: "Alice", Age: 25}
+		bob = User{Firstname: "Bob", Age: 30}
+		carl = User{Firstname: "Carl", Age: 35}
+	})
+	It("returns added and removed elements", func() {
+		diff := collection.DiffSetEqual(
+			collection.NewSetEqual(alice, bob),
+			collection.NewSetEqual(bob, carl),
+		)
+		Expect(diff.Added).To(Equal([]User{carl}))
+		Expect(diff.Removed).To(Equal([]User{alice}))
+	})
+	It("applies a diff", func() {
+		from := collection.NewSetEqual(alice, bob)
+		to := collection.NewSetEqual(bob, carl)
+		collection.ApplySetEqual(from, collection.DiffSetEqual(from, to))
+		Expect(from.Slice()).To(Equal([]User{bob, carl}))
+		Expect(collection.DiffSetEqual(from, to).IsEmpty()).To(BeTrue())
+	})
+})
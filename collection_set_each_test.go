@@ -0,0 +1,113 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+type eachSet interface {
+	Add(elements ...int)
+	Remove(elements ...int)
+	Contains(element int) bool
+	Length() int
+	Each(ctx context.Context, fn func(ctx context.Context, value int) error) error
+	EachLocked(ctx context.Context, fn func(ctx context.Context, value int) error) error
+}
+
+var _ = Describe("Set Each", func() {
+	var ctx context.Context
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+	DescribeTable("iterates over a snapshot",
+		func(newSet func() eachSet) {
+			set := newSet()
+			var values []int
+			err := set.Each(ctx, func(ctx context.Context, value int) error {
+				values = append(values, value)
+				set.Remove(value)
+				set.Add(value + 10)
+				Expect(set.Contains(value + 10)).To(BeTrue())
+				Expect(set.Length()).To(BeNumerically(">", 0))
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(ConsistOf(1, 2, 3))
+			Expect(set.Contains(1)).To(BeFalse())
+			Expect(set.Contains(11)).To(BeTrue())
+			Expect(set.Contains(13)).To(BeTrue())
+		},
+		Entry("Set", func() eachSet { return collection.NewSet(1, 2, 3) }),
+		Entry("LinkedSet", func() eachSet { return collection.NewLinkedSet(1, 2, 3) }),
+		Entry("SortedSet", func() eachSet { return collection.NewSortedSet(1, 2, 3) }),
+		Entry("BoundedSet", func() eachSet { return collection.NewBoundedSet(10, 1, 2, 3) }),
+		Entry("BitmapSet", func() eachSet { return collection.NewBitmapSet(1, 2, 3) }),
+		Entry("ExpiringSet", func() eachSet {
+			return collection.NewExpiringSet(collection.ExpiringSetOptions{}, 1, 2, 3)
+		}),
+	)
+	DescribeTable("EachLocked iterates over all elements",
+		func(newSet func() eachSet) {
+			set := newSet()
+			var values []int
+			err := set.EachLocked(ctx, func(ctx context.Context, value int) error {
+				values = append(values, value)
+				return nil
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(values).To(ConsistOf(1, 2, 3))
+		},
+		Entry("Set", func() eachSet { return collection.NewSet(1, 2, 3) }),
+		Entry("LinkedSet", func() eachSet { return collection.NewLinkedSet(1, 2, 3) }),
+		Entry("SortedSet", func() eachSet { return collection.NewSortedSet(1, 2, 3) }),
+		Entry("BoundedSet", func() eachSet { return collection.NewBoundedSet(10, 1, 2, 3) }),
+		Entry("BitmapSet", func() eachSet { return collection.NewBitmapSet(1, 2, 3) }),
+		Entry("ExpiringSet", func() eachSet {
+			return collection.NewExpiringSet(collection.ExpiringSetOptions{}, 1, 2, 3)
+		}),
+	)
+	It("allows changes of SetHashCode and SetEqual from fn", func() {
+		users := []User{{Firstname: "a"}, {Firstname: "b"}}
+		hashCodeSet := collection.NewSetHashCode(users...)
+		err := hashCodeSet.Each(ctx, func(ctx context.Context, value User) error {
+			hashCodeSet.Remove(value)
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hashCodeSet.Length()).To(Equal(0))
+
+		equalSet := collection.NewSetEqual(users...)
+		err = equalSet.Each(ctx, func(ctx context.Context, value User) error {
+			equalSet.Remove(value)
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(equalSet.Length()).To(Equal(0))
+	})
+	It("allows changes of maps from fn", func() {
+		syncMap := collection.NewSyncMapFromMap(map[string]int{"a": 1, "b": 2})
+		err := syncMap.Each(ctx, func(ctx context.Context, key string, value int) error {
+			syncMap.Delete(key)
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(syncMap.Length()).To(Equal(0))
+
+		multiMap := collection.NewMultiMap[string, int]()
+		multiMap.Put("a", 1, 2)
+		err = multiMap.Each(ctx, func(ctx context.Context, key string, value int) error {
+			multiMap.Remove(key, value)
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(multiMap.Length()).To(Equal(0))
+	})
+})
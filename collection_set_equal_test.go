@@ -6,6 +6,7 @@ package collection_test
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -467,3 +468,90 @@ var _ = Describe("SetEqual", func() {
 		})
 	})
 })
+
+type bucketedUser struct {
+	ID   uint64
+	Name string
+}
+
+func (b bucketedUser) Equal(other bucketedUser) bool {
+	return b.ID == other.ID
+}
+
+// EqualHash deliberately collides to exercise bucket handling.
+func (b bucketedUser) EqualHash() uint64 {
+	return b.ID % 4
+}
+
+var _ = Describe("SetEqual with HasEqualHash", func() {
+	var set collection.SetEqual[bucketedUser]
+	BeforeEach(func() {
+		set = collection.NewSetEqual[bucketedUser]()
+	})
+	It("ignores equal elements with the same hash", func() {
+		set.Add(bucketedUser{ID: 1, Name: "a"}, bucketedUser{ID: 1, Name: "b"})
+		Expect(set.Slice()).To(Equal([]bucketedUser{{ID: 1, Name: "a"}}))
+	})
+	It("keeps different elements sharing a bucket", func() {
+		set.Add(bucketedUser{ID: 1}, bucketedUser{ID: 5}, bucketedUser{ID: 9})
+		Expect(set.Length()).To(Equal(3))
+		Expect(set.Contains(bucketedUser{ID: 5})).To(BeTrue())
+		Expect(set.Contains(bucketedUser{ID: 13})).To(BeFalse())
+	})
+	It("removes only the equal element from a shared bucket", func() {
+		set.Add(bucketedUser{ID: 1}, bucketedUser{ID: 5}, bucketedUser{ID: 9})
+		set.Remove(bucketedUser{ID: 5})
+		Expect(set.Slice()).To(Equal([]bucketedUser{{ID: 1}, {ID: 9}}))
+		set.Add(bucketedUser{ID: 5})
+		Expect(set.Slice()).To(Equal([]bucketedUser{{ID: 1}, {ID: 9}, {ID: 5}}))
+	})
+	It("keeps insertion order after removes", func() {
+		for i := uint64(0); i < 10; i++ {
+			set.Add(bucketedUser{ID: i})
+		}
+		set.Remove(bucketedUser{ID: 0}, bucketedUser{ID: 4}, bucketedUser{ID: 9})
+		ids := make([]uint64, 0)
+		for _, user := range set.Slice() {
+			ids = append(ids, user.ID)
+		}
+		Expect(ids).To(Equal([]uint64{1, 2, 3, 5, 6, 7, 8}))
+	})
+	It("keeps buckets intact for clones and json round trips", func() {
+		set.Add(bucketedUser{ID: 1}, bucketedUser{ID: 2}, bucketedUser{ID: 5})
+		clone := set.Clone()
+		clone.Remove(bucketedUser{ID: 5})
+		Expect(set.Contains(bucketedUser{ID: 5})).To(BeTrue())
+		Expect(clone.Contains(bucketedUser{ID: 5})).To(BeFalse())
+
+		data, err := json.Marshal(set)
+		Expect(err).To(BeNil())
+		decoded := collection.NewSetEqual[bucketedUser]()
+		Expect(json.Unmarshal(data, decoded)).To(Succeed())
+		Expect(decoded.Slice()).To(Equal(set.Slice()))
+		Expect(decoded.Contains(bucketedUser{ID: 2})).To(BeTrue())
+	})
+	It("handles large sets", func() {
+		elements := make([]largeBucketedUser, 0, 50000)
+		for i := uint64(0); i < 50000; i++ {
+			elements = append(elements, largeBucketedUser{ID: i})
+		}
+		large := collection.NewSetEqual(elements...)
+		large.Add(elements...)
+		Expect(large.Length()).To(Equal(50000))
+		large.Remove(elements[:25000]...)
+		Expect(large.Length()).To(Equal(25000))
+		Expect(large.Contains(largeBucketedUser{ID: 49999})).To(BeTrue())
+	})
+})
+
+type largeBucketedUser struct {
+	ID uint64
+}
+
+func (l largeBucketedUser) Equal(other largeBucketedUser) bool {
+	return l.ID == other.ID
+}
+
+func (l largeBucketedUser) EqualHash() uint64 {
+	return l.ID
+}
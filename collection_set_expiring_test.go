@@ -0,0 +1,136 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("ExpiringSet", func() {
+	var ctx context.Context
+	var now time.Time
+	var set collection.ExpiringSet[string]
+	BeforeEach(func() {
+		ctx = context.Background()
+		now = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		set = collection.NewExpiringSet[string](collection.ExpiringSetOptions{
+			DefaultTTL: time.Minute,
+			Now:        func() time.Time { return now },
+		})
+	})
+	It("expires elements after the default TTL", func() {
+		set.Add("a", "b")
+		Expect(set.Contains("a")).To(BeTrue())
+		Expect(set.Length()).To(Equal(2))
+
+		now = now.Add(time.Minute)
+		Expect(set.Contains("a")).To(BeFalse())
+		Expect(set.Length()).To(Equal(0))
+		Expect(set.String()).To(Equal("ExpiringSet[]"))
+	})
+	It("expires elements after a custom TTL", func() {
+		set.AddWithTTL(time.Second, "short")
+		set.AddWithTTL(time.Hour, "long")
+		set.AddWithTTL(0, "forever")
+
+		now = now.Add(2 * time.Second)
+		Expect(set.Slice()).To(ConsistOf("long", "forever"))
+
+		now = now.Add(24 * time.Hour)
+		Expect(set.Slice()).To(ConsistOf("forever"))
+		expiry, found := set.ExpiresAt("forever")
+		Expect(found).To(BeTrue())
+		Expect(expiry.IsZero()).To(BeTrue())
+	})
+	It("resets the expiry of elements added again", func() {
+		set.Add("a")
+		now = now.Add(30 * time.Second)
+		set.Add("a")
+		now = now.Add(45 * time.Second)
+		Expect(set.Contains("a")).To(BeTrue())
+		expiry, found := set.ExpiresAt("a")
+		Expect(found).To(BeTrue())
+		Expect(expiry).To(Equal(now.Add(15 * time.Second)))
+
+		now = now.Add(15 * time.Second)
+		Expect(set.Contains("a")).To(BeFalse())
+	})
+	It("skips expired elements in Each and set algebra", func() {
+		set.AddWithTTL(time.Second, "a")
+		set.Add("b", "c")
+		now = now.Add(time.Second)
+
+		var visited []string
+		Expect(set.Each(ctx, func(ctx context.Context, value string) error {
+			visited = append(visited, value)
+			return nil
+		})).To(Succeed())
+		Expect(visited).To(ConsistOf("b", "c"))
+		Expect(set.Union(collection.NewSet("a")).Slice()).To(ConsistOf("a", "b", "c"))
+		Expect(set.Intersection(collection.NewSet("a", "b")).Slice()).To(ConsistOf("b"))
+		Expect(set.IsSubsetOf(collection.NewSet("b", "c"))).To(BeTrue())
+	})
+	It("keeps expiries in clones", func() {
+		set.AddWithTTL(time.Second, "a")
+		set.Add("b")
+		clone := set.Without("b")
+		now = now.Add(time.Second)
+		Expect(clone.Length()).To(Equal(0))
+		Expect(set.Slice()).To(ConsistOf("b"))
+	})
+	It("reports expired elements to subscribers", func() {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		events := set.Subscribe(ctx, collection.SubscribeOptions{BufferSize: 10})
+		set.Add("a")
+		Expect(<-events).To(Equal(collection.SetEvent[string]{
+			Type:     collection.SetEventAdded,
+			Elements: []string{"a"},
+		}))
+
+		now = now.Add(time.Minute)
+		set.Cleanup()
+		Expect(<-events).To(Equal(collection.SetEvent[string]{
+			Type:     collection.SetEventRemoved,
+			Elements: []string{"a"},
+		}))
+	})
+	It("writes only live elements to json", func() {
+		set.AddWithTTL(time.Second, "a")
+		set.Add("c", "b")
+		now = now.Add(time.Second)
+		Expect(json.Marshal(set)).To(Equal([]byte(`["b","c"]`)))
+
+		decoded := collection.NewExpiringSet[string](collection.ExpiringSetOptions{})
+		Expect(json.Unmarshal([]byte(`["x","y"]`), decoded)).To(Succeed())
+		Expect(decoded.Slice()).To(ConsistOf("x", "y"))
+	})
+	It("removes expired elements in Run until the context is canceled", func() {
+		ctx, cancel := context.WithCancel(ctx)
+		runSet := collection.NewExpiringSet[int](collection.ExpiringSetOptions{})
+		runSet.AddWithTTL(time.Hour, 1)
+		runSet.AddWithTTL(time.Millisecond, 2)
+		events := runSet.Subscribe(ctx, collection.SubscribeOptions{BufferSize: 10})
+
+		done := make(chan error, 1)
+		go func() {
+			done <- runSet.Run(ctx, time.Millisecond)
+		}()
+		Eventually(events).Should(Receive(Equal(collection.SetEvent[int]{
+			Type:     collection.SetEventRemoved,
+			Elements: []int{2},
+		})))
+		cancel()
+		Eventually(done).Should(Receive(BeNil()))
+		Expect(runSet.Slice()).To(ConsistOf(1))
+	})
+})
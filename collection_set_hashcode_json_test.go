@@ -5,6 +5,7 @@
 package collection_test
 
 import (
+	"cmp"
 	"encoding/json"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -37,6 +38,34 @@ var _ = Describe("SetHashCode JSON", func() {
 		})
 	})
 
+	Context("deterministic output", func() {
+		It("writes elements ordered by hash code", func() {
+			set := collection.NewSetHashCode(
+				account{ID: "3", Name: "carl"},
+				account{ID: "1", Name: "alice"},
+				account{ID: "2", Name: "bob"},
+			)
+			data, err := json.Marshal(set)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(
+				`[{"id":"1","name":"alice"},{"id":"2","name":"bob"},{"id":"3","name":"carl"}]`,
+			))
+		})
+
+		It("writes elements in the order of the given compare function", func() {
+			set := collection.NewSetHashCodeWithCompare(
+				func(a, b account) int {
+					return cmp.Compare(b.Name, a.Name)
+				},
+				account{ID: "1", Name: "alice"},
+				account{ID: "2", Name: "bob"},
+			)
+			data, err := json.Marshal(set.Clone())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(`[{"id":"2","name":"bob"},{"id":"1","name":"alice"}]`))
+		})
+	})
+
 	Context("UnmarshalJSON", func() {
 		It("unmarshals empty JSON array to empty set", func() {
 			set := collection.NewSetHashCode[User]()
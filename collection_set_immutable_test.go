@@ -0,0 +1,204 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("ImmutableSet", func() {
+	var set collection.ImmutableSet[string]
+	var ctx context.Context
+	BeforeEach(func() {
+		set = collection.NewImmutableSet("a", "b", "c", "a")
+		ctx = context.Background()
+	})
+	It("contains unique elements", func() {
+		Expect(set.Length()).To(Equal(3))
+		Expect(set.Slice()).To(ConsistOf("a", "b", "c"))
+		Expect(slices.Collect(set.All())).To(ConsistOf("a", "b", "c"))
+		Expect(set.Contains("a")).To(BeTrue())
+		Expect(set.Contains("z")).To(BeFalse())
+		Expect(set.ContainsAll("a", "b")).To(BeTrue())
+		Expect(set.ContainsAll("a", "z")).To(BeFalse())
+		Expect(set.ContainsAny("z", "c")).To(BeTrue())
+		Expect(set.ContainsAny("y", "z")).To(BeFalse())
+	})
+	It("returns new versions on add and remove", func() {
+		added := set.Add("d")
+		removed := set.Remove("a")
+		Expect(set.Slice()).To(ConsistOf("a", "b", "c"))
+		Expect(added.Slice()).To(ConsistOf("a", "b", "c", "d"))
+		Expect(removed.Slice()).To(ConsistOf("b", "c"))
+	})
+	It("returns the same version if nothing changes", func() {
+		Expect(set.Add("a", "b")).To(BeIdenticalTo(set))
+		Expect(set.Remove("z")).To(BeIdenticalTo(set))
+	})
+	It("compares versions", func() {
+		Expect(set.Equal(set)).To(BeTrue())
+		Expect(set.Add("d").Remove("d").Equal(set)).To(BeTrue())
+		Expect(set.Equal(collection.NewImmutableSet("c", "b", "a"))).To(BeTrue())
+		Expect(set.Equal(set.Add("d"))).To(BeFalse())
+		Expect(set.Equal(set.Remove("a").Add("z"))).To(BeFalse())
+		Expect(collection.NewImmutableSet[string]().Equal(set.Remove("a", "b", "c"))).To(BeTrue())
+	})
+	It("returns strings", func() {
+		Expect(set.Strings()).To(Equal([]string{"a", "b", "c"}))
+		Expect(set.String()).To(Equal("ImmutableSet[a, b, c]"))
+		Expect(collection.NewImmutableSet[string]().String()).To(Equal("ImmutableSet[]"))
+	})
+	It("iterates all elements", func() {
+		var result []string
+		Expect(set.Each(ctx, func(ctx context.Context, value string) error {
+			result = append(result, value)
+			return nil
+		})).To(Succeed())
+		Expect(result).To(ConsistOf("a", "b", "c"))
+	})
+	It("stops iteration on first error", func() {
+		err := set.Each(ctx, func(ctx context.Context, value string) error {
+			return errors.New("banana")
+		})
+		Expect(err).To(MatchError("banana"))
+	})
+	It("converts to and from Set", func() {
+		mutable := set.ToSet()
+		mutable.Add("d")
+		Expect(set.Length()).To(Equal(3))
+		Expect(mutable.Slice()).To(ConsistOf("a", "b", "c", "d"))
+		Expect(collection.NewImmutableSetFromSet(mutable).Equal(set.Add("d"))).To(BeTrue())
+	})
+	It("marshals json and text", func() {
+		data, err := json.Marshal(set)
+		Expect(err).To(BeNil())
+		var elements []string
+		Expect(json.Unmarshal(data, &elements)).To(Succeed())
+		Expect(elements).To(ConsistOf("a", "b", "c"))
+		text, err := set.MarshalText()
+		Expect(err).To(BeNil())
+		Expect(string(text)).To(Equal("a,b,c"))
+	})
+	It("does not modify shared versions in batch operations", func() {
+		base := collection.NewImmutableSet[int]()
+		for i := 0; i < 1000; i++ {
+			base = base.Add(i)
+		}
+		more := make([]int, 0, 1000)
+		for i := 1000; i < 2000; i++ {
+			more = append(more, i)
+		}
+		added := base.Add(more...)
+		removed := added.Remove(more[:500]...)
+		Expect(base.Length()).To(Equal(1000))
+		Expect(base.ContainsAny(more...)).To(BeFalse())
+		Expect(added.Length()).To(Equal(2000))
+		Expect(added.ContainsAll(more...)).To(BeTrue())
+		Expect(removed.Length()).To(Equal(1500))
+		Expect(removed.Remove(more...).Equal(base)).To(BeTrue())
+	})
+	It("matches a reference implementation for random operations", func() {
+		random := rand.New(rand.NewSource(42)) // #nosec G404 -- deterministic test data
+		reference := map[int]bool{}
+		current := collection.NewImmutableSet[int]()
+		versions := []collection.ImmutableSet[int]{current}
+		lengths := []int{0}
+		for i := 0; i < 5000; i++ {
+			value := random.Intn(2000)
+			if random.Intn(3) == 0 {
+				current = current.Remove(value)
+				delete(reference, value)
+			} else {
+				current = current.Add(value)
+				reference[value] = true
+			}
+			if i%500 == 0 {
+				versions = append(versions, current)
+				lengths = append(lengths, len(reference))
+			}
+		}
+		expected := make([]int, 0, len(reference))
+		for value := range reference {
+			expected = append(expected, value)
+		}
+		slices.Sort(expected)
+		result := current.Slice()
+		slices.Sort(result)
+		Expect(result).To(Equal(expected))
+		Expect(current.Equal(collection.NewImmutableSet(expected...))).To(BeTrue())
+		for i, version := range versions {
+			Expect(version.Length()).To(Equal(lengths[i]))
+		}
+		Expect(current.Remove(expected...).Length()).To(Equal(0))
+		Expect(current.Remove(expected...).Equal(versions[0])).To(BeTrue())
+	})
+})
+
+var _ = Describe("ImmutableSetHashCode", func() {
+	var set collection.ImmutableSetHashCode[User]
+	var alice, bob, carl User
+	BeforeEach(func() {
+		alice = User{Firstname: "Alice", Age: 25}
+		bob = User{Firstname: "Bob", Age: 30}
+		carl = User{Firstname: "Carl", Age: 35}
+		set = collection.NewImmutableSetHashCode(alice, bob, alice)
+	})
+	It("contains unique elements", func() {
+		Expect(set.Length()).To(Equal(2))
+		Expect(set.Slice()).To(ConsistOf(alice, bob))
+		Expect(set.Contains(alice)).To(BeTrue())
+		Expect(set.Contains(carl)).To(BeFalse())
+		Expect(set.ContainsAll(alice, bob)).To(BeTrue())
+		Expect(set.ContainsAny(carl, bob)).To(BeTrue())
+	})
+	It("returns new versions on add and remove", func() {
+		added := set.Add(carl)
+		removed := set.Remove(alice)
+		Expect(set.Slice()).To(ConsistOf(alice, bob))
+		Expect(added.Slice()).To(ConsistOf(alice, bob, carl))
+		Expect(removed.Slice()).To(ConsistOf(bob))
+		Expect(set.Add(alice)).To(BeIdenticalTo(set))
+	})
+	It("replaces elements with a present hash code like SetHashCode", func() {
+		before := collection.NewImmutableSetHashCode(keyedUser{ID: 1, Name: "a"})
+		after := before.Add(keyedUser{ID: 1, Name: "b"})
+		Expect(before.Slice()).To(Equal([]keyedUser{{ID: 1, Name: "a"}}))
+		Expect(after.Slice()).To(Equal([]keyedUser{{ID: 1, Name: "b"}}))
+		Expect(after.Add(keyedUser{ID: 1, Name: "b"})).To(BeIdenticalTo(after))
+
+		mutable := before.ToSetHashCode()
+		mutable.Add(keyedUser{ID: 1, Name: "b"})
+		Expect(after.Slice()).To(Equal(mutable.Slice()))
+	})
+	It("compares versions", func() {
+		Expect(set.Add(carl).Remove(carl).Equal(set)).To(BeTrue())
+		Expect(set.Equal(collection.NewImmutableSetHashCode(bob, alice))).To(BeTrue())
+		Expect(set.Equal(set.Add(carl))).To(BeFalse())
+	})
+	It("converts to and from SetHashCode", func() {
+		mutable := set.ToSetHashCode()
+		mutable.Add(carl)
+		Expect(set.Length()).To(Equal(2))
+		Expect(mutable.Slice()).To(ConsistOf(alice, bob, carl))
+		immutable := collection.NewImmutableSetHashCodeFromSetHashCode(mutable)
+		Expect(immutable.Equal(set.Add(carl))).To(BeTrue())
+	})
+	It("marshals json", func() {
+		data, err := json.Marshal(set)
+		Expect(err).To(BeNil())
+		var users []User
+		Expect(json.Unmarshal(data, &users)).To(Succeed())
+		Expect(users).To(ConsistOf(alice, bob))
+	})
+})
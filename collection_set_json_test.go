@@ -5,7 +5,9 @@
 package collection_test
 
 import (
+	"cmp"
 	"encoding/json"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -66,6 +68,102 @@ var _ = Describe("Set JSON", func() {
 		})
 	})
 
+	Context("deterministic output", func() {
+		It("writes ints in natural order", func() {
+			data, err := json.Marshal(collection.NewSet(10, 2, 33, -1))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("[-1,2,10,33]"))
+		})
+
+		It("writes strings in natural order", func() {
+			data, err := json.Marshal(collection.NewSet("cherry", "apple", "banana"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal(`["apple","banana","cherry"]`))
+		})
+
+		It("writes structs ordered by their encoding", func() {
+			type Person struct {
+				Name string `json:"name"`
+			}
+			for i := 0; i < 10; i++ {
+				set := collection.NewSet(
+					Person{Name: "Carl"},
+					Person{Name: "Alice"},
+					Person{Name: "Bob"},
+				)
+				data, err := json.Marshal(set)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(Equal(`[{"name":"Alice"},{"name":"Bob"},{"name":"Carl"}]`))
+			}
+		})
+
+		It("writes elements in the order of the given compare function", func() {
+			set := collection.NewSetWithCompare(func(a, b int) int {
+				return cmp.Compare(b, a)
+			}, 1, 3, 2)
+			data, err := json.Marshal(set)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("[3,2,1]"))
+			data, err = json.Marshal(set.Clone())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(data)).To(Equal("[3,2,1]"))
+			text, err := set.MarshalText()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(text)).To(Equal("3,2,1"))
+		})
+
+		It("keeps the compare function in the results of set algebra", func() {
+			descending := func(a, b int) int {
+				return cmp.Compare(b, a)
+			}
+			set := collection.NewSetWithCompare(descending, 1, 3, 2)
+			other := collection.NewSet(2, 4)
+			for result, expected := range map[collection.Set[int]]string{
+				set.Union(other):               "[4,3,2,1]",
+				set.Intersection(other):        "[2]",
+				set.Difference(other):          "[3,1]",
+				set.SymmetricDifference(other): "[4,3,1]",
+			} {
+				data, err := json.Marshal(result)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(string(data)).To(Equal(expected))
+			}
+
+			users := collection.NewSetHashCodeWithCompare(
+				func(a, b keyedUser) int {
+					return cmp.Compare(b.ID, a.ID)
+				},
+				keyedUser{ID: 1}, keyedUser{ID: 3}, keyedUser{ID: 2},
+			)
+			others := collection.NewSetHashCode(keyedUser{ID: 2}, keyedUser{ID: 4})
+			for result, expected := range map[collection.SetHashCode[keyedUser]]string{
+				users.Union(others):               "[4,3,2,1]",
+				users.Intersection(others):        "[2]",
+				users.Difference(others):          "[3,1]",
+				users.SymmetricDifference(others): "[4,3,1]",
+			} {
+				data, err := json.Marshal(result)
+				Expect(err).NotTo(HaveOccurred())
+				var decoded []keyedUser
+				Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+				ids := make([]int, 0, len(decoded))
+				for _, user := range decoded {
+					ids = append(ids, user.ID)
+				}
+				Expect(json.Marshal(ids)).To(BeEquivalentTo(expected))
+			}
+		})
+
+		It("writes text of non-string types in natural order", func() {
+			text, err := collection.NewSet(10, 2, 33).MarshalText()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(text)).To(Equal("2,10,33"))
+			text, err = collection.NewSet(time.Minute, time.Second).MarshalText()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(text)).To(Equal("1s,1m0s"))
+		})
+	})
+
 	Context("UnmarshalJSON", func() {
 		It("unmarshals empty JSON array to empty set", func() {
 			set := collection.NewSet[int]()
@@ -0,0 +1,121 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("LinkedSet", func() {
+	var set collection.LinkedSet[string]
+	var ctx context.Context
+	BeforeEach(func() {
+		set = collection.NewLinkedSet("c", "a", "b", "a")
+		ctx = context.Background()
+	})
+	It("keeps insertion order", func() {
+		Expect(set.Slice()).To(Equal([]string{"c", "a", "b"}))
+		Expect(slices.Collect(set.All())).To(Equal([]string{"c", "a", "b"}))
+		Expect(set.Length()).To(Equal(3))
+	})
+	It("does not move elements on duplicate add", func() {
+		set.Add("c", "d")
+		Expect(set.Slice()).To(Equal([]string{"c", "a", "b", "d"}))
+	})
+	It("keeps order after remove and re-add", func() {
+		set.Remove("c")
+		set.Add("c")
+		Expect(set.Slice()).To(Equal([]string{"a", "b", "c"}))
+	})
+	It("reports contains", func() {
+		Expect(set.Contains("a")).To(BeTrue())
+		Expect(set.Contains("z")).To(BeFalse())
+		Expect(set.ContainsAll("a", "b")).To(BeTrue())
+		Expect(set.ContainsAll("a", "z")).To(BeFalse())
+		Expect(set.ContainsAny("z", "b")).To(BeTrue())
+		Expect(set.ContainsAny("y", "z")).To(BeFalse())
+	})
+	It("moves elements to front and back", func() {
+		Expect(set.MoveToFront("b")).To(BeTrue())
+		Expect(set.Slice()).To(Equal([]string{"b", "c", "a"}))
+		Expect(set.MoveToBack("b")).To(BeTrue())
+		Expect(set.Slice()).To(Equal([]string{"c", "a", "b"}))
+		Expect(set.MoveToFront("z")).To(BeFalse())
+		Expect(set.MoveToBack("z")).To(BeFalse())
+	})
+	It("iterates in insertion order", func() {
+		var result []string
+		Expect(set.Each(ctx, func(ctx context.Context, value string) error {
+			result = append(result, value)
+			return nil
+		})).To(Succeed())
+		Expect(result).To(Equal([]string{"c", "a", "b"}))
+	})
+	It("stops iteration on first error", func() {
+		err := set.Each(ctx, func(ctx context.Context, value string) error {
+			return errors.New("banana")
+		})
+		Expect(err).To(MatchError("banana"))
+	})
+	It("returns sorted strings and an ordered string", func() {
+		Expect(set.Strings()).To(Equal([]string{"a", "b", "c"}))
+		Expect(set.String()).To(Equal("LinkedSet[c, a, b]"))
+	})
+	It("clones keeping order", func() {
+		clone := set.Clone()
+		clone.Add("d")
+		Expect(set.Slice()).To(Equal([]string{"c", "a", "b"}))
+		Expect(clone.Slice()).To(Equal([]string{"c", "a", "b", "d"}))
+		Expect(set.Without("a").Slice()).To(Equal([]string{"c", "b"}))
+	})
+	It("keeps order in set algebra", func() {
+		other := collection.NewLinkedSet("d", "b")
+		Expect(set.Union(other).Slice()).To(Equal([]string{"c", "a", "b", "d"}))
+		Expect(set.Intersection(other).Slice()).To(Equal([]string{"b"}))
+		Expect(set.Difference(other).Slice()).To(Equal([]string{"c", "a"}))
+		Expect(set.SymmetricDifference(other).Slice()).To(Equal([]string{"c", "a", "d"}))
+		Expect(set.IsSubsetOf(other)).To(BeFalse())
+		Expect(set.IsSupersetOf(collection.NewSet("a"))).To(BeTrue())
+		Expect(set.IsDisjoint(collection.NewSet("x"))).To(BeTrue())
+	})
+	It("can be combined with other Set implementations", func() {
+		result := collection.SetUnion[string](set, collection.NewSet("x"))
+		Expect(result.Slice()).To(ConsistOf("a", "b", "c", "x"))
+	})
+	It("marshals json in insertion order", func() {
+		data, err := json.Marshal(set)
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(Equal(`["c","a","b"]`))
+	})
+	It("unmarshals json keeping order", func() {
+		Expect(json.Unmarshal([]byte(`["z","y","z","x"]`), set)).To(Succeed())
+		Expect(set.Slice()).To(Equal([]string{"z", "y", "x"}))
+	})
+	It("marshals text in insertion order", func() {
+		data, err := set.MarshalText()
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(Equal("c,a,b"))
+	})
+	It("unmarshals text keeping order", func() {
+		Expect(set.UnmarshalText([]byte("host-b, host-a,,host-c"))).To(Succeed())
+		Expect(set.Slice()).To(Equal([]string{"host-b", "host-a", "host-c"}))
+	})
+	It("parses from string keeping order", func() {
+		flags := collection.ParseLinkedSetFromString[CustomStringType]("zeta, alpha,beta,alpha")
+		Expect(flags.Slice()).To(Equal([]CustomStringType{"zeta", "alpha", "beta"}))
+	})
+	It("can be used as Set", func() {
+		var s collection.Set[string] = set
+		Expect(s.Length()).To(Equal(3))
+	})
+})
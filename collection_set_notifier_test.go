@@ -0,0 +1,203 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("Set Subscribe", func() {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	var options collection.SubscribeOptions
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		options = collection.SubscribeOptions{BufferSize: 10}
+	})
+	AfterEach(func() {
+		cancel()
+	})
+	It("reports added and removed elements only", func() {
+		set := collection.NewSet("a")
+		events := set.Subscribe(ctx, options)
+		set.Add("a", "b")
+		set.Add("b")
+		set.Remove("z", "a")
+		set.Remove("z")
+		Eventually(events).Should(Receive(Equal(collection.SetEvent[string]{
+			Type:     collection.SetEventAdded,
+			Elements: []string{"b"},
+		})))
+		Eventually(events).Should(Receive(Equal(collection.SetEvent[string]{
+			Type:     collection.SetEventRemoved,
+			Elements: []string{"a"},
+		})))
+		Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+	})
+	It("reports the difference after unmarshal", func() {
+		set := collection.NewSet("a", "b")
+		events := set.Subscribe(ctx, options)
+		Expect(json.Unmarshal([]byte(`["b","c"]`), set)).To(Succeed())
+		Eventually(events).Should(Receive(Equal(collection.SetEvent[string]{
+			Type:     collection.SetEventRemoved,
+			Elements: []string{"a"},
+		})))
+		Eventually(events).Should(Receive(Equal(collection.SetEvent[string]{
+			Type:     collection.SetEventAdded,
+			Elements: []string{"c"},
+		})))
+	})
+	It("delivers to all subscribers", func() {
+		set := collection.NewSet[int]()
+		first := set.Subscribe(ctx, options)
+		second := set.Subscribe(ctx, options)
+		set.Add(1)
+		Eventually(first).Should(Receive(HaveField("Elements", []int{1})))
+		Eventually(second).Should(Receive(HaveField("Elements", []int{1})))
+	})
+	It("closes the channel once the context is canceled", func() {
+		set := collection.NewSet[int]()
+		events := set.Subscribe(ctx, options)
+		cancel()
+		Eventually(events).Should(BeClosed())
+		set.Add(1)
+	})
+	It("does not block on a full buffer with DeliveryDrop", func() {
+		set := collection.NewSet[int]()
+		events := set.Subscribe(ctx, collection.SubscribeOptions{
+			BufferSize: 1,
+			Delivery:   collection.DeliveryDrop,
+		})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			set.Add(1)
+			set.Add(2)
+			set.Add(3)
+		}()
+		Eventually(done).Should(BeClosed())
+		Expect(set.Length()).To(Equal(3))
+		Eventually(events).Should(Receive(HaveField("Elements", []int{1})))
+		Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+	})
+	It("queues events with DeliveryBlock without blocking writers", func() {
+		set := collection.NewSet[int]()
+		events := set.Subscribe(ctx, collection.SubscribeOptions{})
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			set.Add(1)
+			set.Add(2)
+		}()
+		Eventually(done).Should(BeClosed())
+		Eventually(events).Should(Receive(HaveField("Elements", []int{1})))
+		Eventually(events).Should(Receive(HaveField("Elements", []int{2})))
+	})
+	It("closes the channel of a canceled subscriber with queued events", func() {
+		set := collection.NewSet[int]()
+		events := set.Subscribe(ctx, collection.SubscribeOptions{})
+		set.Add(1)
+		set.Add(2)
+		cancel()
+		Eventually(func() bool {
+			_, ok := <-events
+			return ok
+		}).Should(BeFalse())
+	})
+	It("allows a subscriber to use the set during concurrent writes", func() {
+		set := collection.NewSet[int]()
+		events := set.Subscribe(ctx, collection.SubscribeOptions{})
+		go func() {
+			defer GinkgoRecover()
+			for event := range events {
+				set.Contains(1)
+				if event.Type == collection.SetEventAdded {
+					set.Replace(event.Elements[0], -event.Elements[0])
+				}
+			}
+		}()
+		var wg sync.WaitGroup
+		for i := 1; i <= 4; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					set.Add(i*1000 + j)
+					set.Replace(i*1000+j, i*1000+j+500)
+				}
+			}(i)
+		}
+		done := make(chan struct{})
+		go func() {
+			wg.Wait()
+			close(done)
+		}()
+		Eventually(done, 10*time.Second).Should(BeClosed())
+	})
+	It("reports changes of a LinkedSet in insertion order", func() {
+		set := collection.NewLinkedSet("a")
+		events := set.Subscribe(ctx, options)
+		set.Add("c", "a", "b")
+		Eventually(events).Should(Receive(Equal(collection.SetEvent[string]{
+			Type:     collection.SetEventAdded,
+			Elements: []string{"c", "b"},
+		})))
+	})
+	It("reports changes of a SortedSet", func() {
+		set := collection.NewSortedSet(1, 2)
+		events := set.Subscribe(ctx, options)
+		set.Add(2, 3)
+		set.Remove(1, 4)
+		Eventually(events).Should(Receive(Equal(collection.SetEvent[int]{
+			Type:     collection.SetEventAdded,
+			Elements: []int{3},
+		})))
+		Eventually(events).Should(Receive(Equal(collection.SetEvent[int]{
+			Type:     collection.SetEventRemoved,
+			Elements: []int{1},
+		})))
+	})
+	It("reports changes of a SetHashCode", func() {
+		alice := User{Firstname: "Alice", Age: 25}
+		bob := User{Firstname: "Bob", Age: 30}
+		set := collection.NewSetHashCode(alice)
+		events := set.Subscribe(ctx, options)
+		set.Add(alice, bob)
+		set.Remove(alice)
+		Eventually(events).Should(Receive(Equal(collection.SetEvent[User]{
+			Type:     collection.SetEventAdded,
+			Elements: []User{bob},
+		})))
+		Eventually(events).Should(Receive(Equal(collection.SetEvent[User]{
+			Type:     collection.SetEventRemoved,
+			Elements: []User{alice},
+		})))
+	})
+	It("reports changes of a SetEqual", func() {
+		alice := User{Firstname: "Alice", Age: 25}
+		bob := User{Firstname: "Bob", Age: 30}
+		set := collection.NewSetEqual(alice)
+		events := set.Subscribe(ctx, options)
+		set.Add(alice, bob)
+		Expect(json.Unmarshal([]byte(`[{"Firstname":"Bob","Age":30}]`), set)).To(Succeed())
+		Eventually(events).Should(Receive(Equal(collection.SetEvent[User]{
+			Type:     collection.SetEventAdded,
+			Elements: []User{bob},
+		})))
+		Eventually(events).Should(Receive(Equal(collection.SetEvent[User]{
+			Type:     collection.SetEventRemoved,
+			Elements: []User{alice},
+		})))
+		Consistently(events, 50*time.Millisecond).ShouldNot(Receive())
+	})
+})
@@ -0,0 +1,170 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+type intTextCodec struct{}
+
+func (intTextCodec) Encode(element int) ([]byte, error) {
+	return []byte(strconv.Itoa(element)), nil
+}
+
+func (intTextCodec) Decode(data []byte) (int, error) {
+	return strconv.Atoi(string(data))
+}
+
+var _ = Describe("PersistentSet", func() {
+	var ctx context.Context
+	var path string
+	open := func() collection.PersistentSet[string] {
+		options := collection.PersistentSetOptions[string]{}
+		set, err := collection.NewPersistentSet(ctx, path, options)
+		Expect(err).NotTo(HaveOccurred())
+		return set
+	}
+	BeforeEach(func() {
+		ctx = context.Background()
+		path = filepath.Join(GinkgoT().TempDir(), "set.log")
+	})
+	It("recovers changes from the log", func() {
+		set := open()
+		set.Add("a", "b", "c")
+		set.Remove("b")
+		Expect(set.Close()).To(Succeed())
+
+		reopened := open()
+		defer reopened.Close()
+		Expect(reopened.Strings()).To(Equal([]string{"a", "c"}))
+		Expect(reopened.String()).To(Equal("PersistentSet[a, c]"))
+	})
+	It("compacts the log into a snapshot", func() {
+		set := open()
+		set.Add("a", "b")
+		set.Remove("a")
+		Expect(set.Compact()).To(Succeed())
+		set.Add("c")
+		Expect(set.Close()).To(Succeed())
+
+		reopened := open()
+		defer reopened.Close()
+		Expect(reopened.Strings()).To(Equal([]string{"b", "c"}))
+		Expect(path + ".snapshot").To(BeAnExistingFile())
+	})
+	It("truncates a partially written change", func() {
+		set := open()
+		set.Add("a")
+		Expect(set.Close()).To(Succeed())
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = file.Write([]byte{1, 10, '"', 'b'})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(file.Close()).To(Succeed())
+
+		reopened := open()
+		Expect(reopened.Strings()).To(Equal([]string{"a"}))
+		truncated, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(truncated.Size()).To(Equal(info.Size()))
+
+		reopened.Add("c")
+		Expect(reopened.Close()).To(Succeed())
+		Expect(open().Strings()).To(Equal([]string{"a", "c"}))
+	})
+	It("rejects a log with a corrupt change followed by more changes", func() {
+		set := open()
+		set.Add("a")
+		Expect(set.Close()).To(Succeed())
+		info, err := os.Stat(path)
+		Expect(err).NotTo(HaveOccurred())
+		set = open()
+		set.Add("b")
+		set.Add("c")
+		Expect(set.Close()).To(Succeed())
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		data[info.Size()+3]++
+		Expect(os.WriteFile(path, data, 0600)).To(Succeed())
+
+		_, err = collection.NewPersistentSet(ctx, path, collection.PersistentSetOptions[string]{})
+		Expect(err).To(HaveOccurred())
+		unchanged, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unchanged).To(Equal(data))
+	})
+	It("rejects a corrupt snapshot", func() {
+		Expect(os.WriteFile(path+".snapshot", []byte{1, 1, 3}, 0600)).To(Succeed())
+		_, err := collection.NewPersistentSet(ctx, path, collection.PersistentSetOptions[string]{})
+		Expect(err).To(HaveOccurred())
+	})
+	It("logs the changes of UnmarshalJSON", func() {
+		set := open()
+		set.Add("a", "b")
+		Expect(json.Unmarshal([]byte(`["b","c"]`), set)).To(Succeed())
+		Expect(set.Close()).To(Succeed())
+		Expect(open().Strings()).To(Equal([]string{"b", "c"}))
+	})
+	It("uses the codec of the options", func() {
+		options := collection.PersistentSetOptions[int]{
+			Fsync: collection.FsyncNever,
+			Codec: intTextCodec{},
+		}
+		set, err := collection.NewPersistentSet(ctx, path, options)
+		Expect(err).NotTo(HaveOccurred())
+		set.Add(42)
+		Expect(set.Sync()).To(Succeed())
+		Expect(set.Close()).To(Succeed())
+
+		data, err := os.ReadFile(path)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(ContainSubstring("42"))
+		reopened, err := collection.NewPersistentSet(ctx, path, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(reopened.Contains(42)).To(BeTrue())
+	})
+	It("reports changes after Close", func() {
+		set := open()
+		Expect(set.Close()).To(Succeed())
+		Expect(set.Err()).NotTo(HaveOccurred())
+		set.Add("a")
+		Expect(set.Contains("a")).To(BeTrue())
+		Expect(set.Err()).To(HaveOccurred())
+		Expect(set.Compact()).NotTo(Succeed())
+	})
+	It("compacts on every tick of Run", func() {
+		set := open()
+		defer set.Close()
+		set.Add("a")
+		runCtx, cancel := context.WithCancel(ctx)
+		done := make(chan error)
+		go func() {
+			done <- set.Run(runCtx, time.Millisecond)
+		}()
+		Eventually(path + ".snapshot").Should(BeAnExistingFile())
+		cancel()
+		Expect(<-done).To(Succeed())
+	})
+	It("supports set algebra with other sets", func() {
+		set := open()
+		defer set.Close()
+		set.Add("a", "b")
+		Expect(set.Intersection(collection.NewSet("b", "c")).Slice()).To(Equal([]string{"b"}))
+		Expect(collection.NewSet("a").IsSubsetOf(set)).To(BeTrue())
+	})
+})
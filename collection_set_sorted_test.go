@@ -0,0 +1,215 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"cmp"
+	"context"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"slices"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("SortedSet", func() {
+	var set collection.SortedSet[int]
+	var ctx context.Context
+	BeforeEach(func() {
+		set = collection.NewSortedSet(10, 2, 33, 2, 7)
+		ctx = context.Background()
+	})
+	It("keeps elements sorted and unique", func() {
+		Expect(set.Slice()).To(Equal([]int{2, 7, 10, 33}))
+		Expect(set.Length()).To(Equal(4))
+	})
+	It("uses numeric order for strings", func() {
+		Expect(set.Strings()).To(Equal([]string{"2", "7", "10", "33"}))
+		Expect(set.String()).To(Equal("SortedSet[2, 7, 10, 33]"))
+	})
+	It("adds and removes elements", func() {
+		set.Add(5, 40)
+		set.Remove(10, 99)
+		Expect(set.Slice()).To(Equal([]int{2, 5, 7, 33, 40}))
+		Expect(set.Contains(10)).To(BeFalse())
+		Expect(set.ContainsAll(2, 5)).To(BeTrue())
+		Expect(set.ContainsAll(2, 10)).To(BeFalse())
+		Expect(set.ContainsAny(10, 40)).To(BeTrue())
+		Expect(set.ContainsAny(10, 11)).To(BeFalse())
+	})
+	It("returns min and max", func() {
+		minValue, ok := set.Min()
+		Expect(ok).To(BeTrue())
+		Expect(minValue).To(Equal(2))
+		maxValue, ok := set.Max()
+		Expect(ok).To(BeTrue())
+		Expect(maxValue).To(Equal(33))
+	})
+	It("returns false for min and max of empty set", func() {
+		empty := collection.NewSortedSet[int]()
+		_, ok := empty.Min()
+		Expect(ok).To(BeFalse())
+		_, ok = empty.Max()
+		Expect(ok).To(BeFalse())
+	})
+	DescribeTable("Floor",
+		func(element int, expected int, expectedOK bool) {
+			result, ok := set.Floor(element)
+			Expect(ok).To(Equal(expectedOK))
+			Expect(result).To(Equal(expected))
+		},
+		Entry("exact match", 7, 7, true),
+		Entry("between elements", 9, 7, true),
+		Entry("above max", 100, 33, true),
+		Entry("below min", 1, 0, false),
+	)
+	DescribeTable("Ceiling",
+		func(element int, expected int, expectedOK bool) {
+			result, ok := set.Ceiling(element)
+			Expect(ok).To(Equal(expectedOK))
+			Expect(result).To(Equal(expected))
+		},
+		Entry("exact match", 7, 7, true),
+		Entry("between elements", 8, 10, true),
+		Entry("below min", 1, 2, true),
+		Entry("above max", 100, 0, false),
+	)
+	DescribeTable("Range",
+		func(from int, to int, expected []int) {
+			Expect(set.Range(from, to)).To(Equal(expected))
+		},
+		Entry("full range", 0, 100, []int{2, 7, 10, 33}),
+		Entry("includes from, excludes to", 7, 33, []int{7, 10}),
+		Entry("empty range", 11, 33, []int{}),
+		Entry("inverted range", 33, 2, []int{}),
+	)
+	DescribeTable("Rank",
+		func(element int, expected int) {
+			Expect(set.Rank(element)).To(Equal(expected))
+		},
+		Entry("min", 2, 0),
+		Entry("element", 10, 2),
+		Entry("missing element", 8, 2),
+		Entry("below min", 1, 0),
+		Entry("above max", 100, 4),
+	)
+	It("iterates in ascending order", func() {
+		var result []int
+		Expect(set.Each(ctx, func(ctx context.Context, value int) error {
+			result = append(result, value)
+			return nil
+		})).To(Succeed())
+		Expect(result).To(Equal([]int{2, 7, 10, 33}))
+		Expect(slices.Collect(set.All())).To(Equal([]int{2, 7, 10, 33}))
+	})
+	It("stops iteration on first error", func() {
+		count := 0
+		err := set.Each(ctx, func(ctx context.Context, value int) error {
+			count++
+			return errors.New("banana")
+		})
+		Expect(err).To(MatchError("banana"))
+		Expect(count).To(Equal(1))
+	})
+	It("clones and removes without modifying the original", func() {
+		clone := set.Clone()
+		clone.Add(1)
+		without := set.Without(2, 7)
+		Expect(set.Slice()).To(Equal([]int{2, 7, 10, 33}))
+		Expect(clone.Slice()).To(Equal([]int{1, 2, 7, 10, 33}))
+		Expect(without.Slice()).To(Equal([]int{10, 33}))
+	})
+	It("marshals and unmarshals json in order", func() {
+		data, err := json.Marshal(set)
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(Equal(`[2,7,10,33]`))
+		decoded := collection.NewSortedSet[int]()
+		Expect(json.Unmarshal([]byte(`[5,1,5,3]`), decoded)).To(Succeed())
+		Expect(decoded.Slice()).To(Equal([]int{1, 3, 5}))
+	})
+	It("marshals text in order", func() {
+		var marshaler encoding.TextMarshaler = set
+		data, err := marshaler.MarshalText()
+		Expect(err).To(BeNil())
+		Expect(string(data)).To(Equal("2,7,10,33"))
+	})
+	It("unmarshals text for string types", func() {
+		strings := collection.NewSortedSet[CustomStringType]()
+		Expect(strings.UnmarshalText([]byte("b, a,,c"))).To(Succeed())
+		Expect(strings.Slice()).To(Equal([]CustomStringType{"a", "b", "c"}))
+	})
+	It("matches a reference implementation for random operations", func() {
+		random := rand.New(rand.NewSource(42)) // #nosec G404 -- deterministic test data
+		reference := map[int]bool{}
+		set = collection.NewSortedSet[int]()
+		for i := 0; i < 5000; i++ {
+			value := random.Intn(500)
+			if random.Intn(3) == 0 {
+				set.Remove(value)
+				delete(reference, value)
+			} else {
+				set.Add(value)
+				reference[value] = true
+			}
+		}
+		expected := make([]int, 0, len(reference))
+		for value := range reference {
+			expected = append(expected, value)
+		}
+		slices.Sort(expected)
+		Expect(set.Slice()).To(Equal(expected))
+		for i, value := range expected {
+			Expect(set.Rank(value)).To(Equal(i))
+		}
+	})
+	It("is safe for concurrent use", func() {
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(offset int) {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					set.Add(offset*100 + j)
+					set.Contains(j)
+				}
+			}(i + 1)
+		}
+		wg.Wait()
+		Expect(set.Length()).To(Equal(1004))
+	})
+})
+
+var _ = Describe("SortedSetFunc", func() {
+	It("orders by the given compare function", func() {
+		byAge := func(a, b User) int {
+			return cmp.Compare(a.Age, b.Age)
+		}
+		set := collection.NewSortedSetFunc(
+			byAge,
+			User{Firstname: "Carl", Age: 40},
+			User{Firstname: "Alice", Age: 20},
+			User{Firstname: "Bob", Age: 30},
+		)
+		Expect(set.Slice()).To(Equal([]User{
+			{Firstname: "Alice", Age: 20},
+			{Firstname: "Bob", Age: 30},
+			{Firstname: "Carl", Age: 40},
+		}))
+		Expect(set.Range(User{Age: 25}, User{Age: 50})).To(HaveLen(2))
+		Expect(set.Contains(User{Age: 30})).To(BeTrue())
+	})
+	It("supports descending order", func() {
+		set := collection.NewSortedSetFunc(func(a, b int) int {
+			return cmp.Compare(b, a)
+		}, 1, 3, 2)
+		Expect(set.Slice()).To(Equal([]int{3, 2, 1}))
+	})
+})
@@ -8,6 +8,9 @@ import (
 	"context"
 	"encoding"
 	"errors"
+	"net/netip"
+	"strconv"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -519,6 +522,92 @@ var _ = Describe("Set", func() {
 		})
 	})
 
+	Context("UnmarshalText with non-string types", func() {
+		It("unmarshals into Set[int]", func() {
+			set := collection.NewSet[int]()
+			Expect(set.UnmarshalText([]byte("1, 2,3,-4"))).To(Succeed())
+			Expect(set.Slice()).To(ConsistOf(1, 2, 3, -4))
+		})
+
+		It("unmarshals into Set[uint8], Set[float64] and Set[bool]", func() {
+			uints := collection.NewSet[uint8]()
+			Expect(uints.UnmarshalText([]byte("1,255"))).To(Succeed())
+			Expect(uints.Slice()).To(ConsistOf(uint8(1), uint8(255)))
+
+			floats := collection.NewSet[float64]()
+			Expect(floats.UnmarshalText([]byte("1.5,2"))).To(Succeed())
+			Expect(floats.Slice()).To(ConsistOf(1.5, 2.0))
+
+			bools := collection.NewSet[bool]()
+			Expect(bools.UnmarshalText([]byte("true,false,true"))).To(Succeed())
+			Expect(bools.Slice()).To(ConsistOf(true, false))
+		})
+
+		It("unmarshals into Set[time.Duration]", func() {
+			set := collection.NewSet[time.Duration]()
+			Expect(set.UnmarshalText([]byte("5s,1m"))).To(Succeed())
+			Expect(set.Slice()).To(ConsistOf(5*time.Second, time.Minute))
+		})
+
+		It("uses encoding.TextUnmarshaler of the element", func() {
+			set := collection.NewSet[netip.Addr]()
+			Expect(set.UnmarshalText([]byte("127.0.0.1,::1"))).To(Succeed())
+			Expect(set.Slice()).To(ConsistOf(
+				netip.MustParseAddr("127.0.0.1"),
+				netip.MustParseAddr("::1"),
+			))
+		})
+
+		It("round-trips marshal/unmarshal", func() {
+			original := collection.NewSet(3, 1, 2)
+			data, err := original.MarshalText()
+			Expect(err).NotTo(HaveOccurred())
+			reconstructed := collection.NewSet[int]()
+			Expect(reconstructed.UnmarshalText(data)).To(Succeed())
+			Expect(reconstructed.Slice()).To(ConsistOf(1, 2, 3))
+		})
+
+		It("returns an error and keeps the set for invalid values", func() {
+			set := collection.NewSet(1)
+			err := set.UnmarshalText([]byte("2,banana"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`parse "banana" failed`))
+			Expect(set.Slice()).To(ConsistOf(1))
+		})
+
+		It("returns an error for overflowing values", func() {
+			set := collection.NewSet[int8]()
+			Expect(set.UnmarshalText([]byte("128"))).NotTo(Succeed())
+		})
+
+		It("returns an error for unsupported types", func() {
+			set := collection.NewSet[struct{ Name string }]()
+			err := set.UnmarshalText([]byte("a"))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("unsupported element type"))
+		})
+	})
+	Context("ParseSetFunc", func() {
+		It("parses comma-separated values with the given parser", func() {
+			set, err := collection.ParseSetFunc(" 80, 443,,80", strconv.Atoi)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(set.Slice()).To(ConsistOf(80, 443))
+		})
+
+		It("handles empty string", func() {
+			set, err := collection.ParseSetFunc("", strconv.Atoi)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(set.Length()).To(Equal(0))
+		})
+
+		It("returns an error if parse fails", func() {
+			set, err := collection.ParseSetFunc("80,http", strconv.Atoi)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`parse "http" failed`))
+			Expect(set).To(BeNil())
+		})
+	})
+
 	Context("Each", func() {
 		It("returns nil for empty set", func() {
 			err := set.Each(ctx, func(ctx context.Context, u User) error {
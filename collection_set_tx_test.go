@@ -0,0 +1,222 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+// keyedUser is identified by ID, so users with the same ID but another Name are equal.
+type keyedUser struct {
+	ID   int
+	Name string
+}
+
+func (u keyedUser) HashCode() string {
+	return strconv.Itoa(u.ID)
+}
+
+func (u keyedUser) Equal(other keyedUser) bool {
+	return u.ID == other.ID
+}
+
+// keyedUserSet is the part of SetHashCode and SetEqual used to test Replace.
+type keyedUserSet interface {
+	Replace(oldElement keyedUser, newElement keyedUser) bool
+	Slice() []keyedUser
+	Subscribe(
+		ctx context.Context,
+		options collection.SubscribeOptions,
+	) <-chan collection.SetEvent[keyedUser]
+}
+
+var _ = Describe("Set Update", func() {
+	DescribeTable("AddIfAbsent and RemoveIfPresent report changes",
+		func(newSet func() collection.Set[int]) {
+			set := newSet()
+			Expect(set.AddIfAbsent(1)).To(BeTrue())
+			Expect(set.AddIfAbsent(1)).To(BeFalse())
+			Expect(set.RemoveIfPresent(1)).To(BeTrue())
+			Expect(set.RemoveIfPresent(1)).To(BeFalse())
+			Expect(set.Length()).To(Equal(0))
+		},
+		Entry("Set", func() collection.Set[int] { return collection.NewSet[int]() }),
+		Entry("LinkedSet", func() collection.Set[int] { return collection.NewLinkedSet[int]() }),
+		Entry("BoundedSet", func() collection.Set[int] { return collection.NewBoundedSet[int](2) }),
+		Entry("BitmapSet", func() collection.Set[int] { return collection.NewBitmapSet[int]() }),
+		Entry("ExpiringSet", func() collection.Set[int] {
+			return collection.NewExpiringSet[int](collection.ExpiringSetOptions{})
+		}),
+	)
+	DescribeTable("Replace swaps present elements",
+		func(newSet func() collection.Set[int]) {
+			set := newSet()
+			Expect(set.Replace(3, 4)).To(BeFalse())
+			Expect(set.Replace(1, 3)).To(BeTrue())
+			Expect(set.Slice()).To(ConsistOf(2, 3))
+		},
+		Entry("Set", func() collection.Set[int] { return collection.NewSet(1, 2) }),
+		Entry("LinkedSet", func() collection.Set[int] { return collection.NewLinkedSet(1, 2) }),
+		Entry("BoundedSet at capacity", func() collection.Set[int] {
+			return collection.NewBoundedSet(2, 1, 2)
+		}),
+		Entry("BitmapSet", func() collection.Set[int] { return collection.NewBitmapSet(1, 2) }),
+	)
+	DescribeTable("CompareAndSwap replaces expected elements",
+		func(newSet func() collection.Set[int]) {
+			set := newSet()
+			Expect(set.CompareAndSwap([]int{1}, []int{5})).To(BeFalse())
+			Expect(set.CompareAndSwap([]int{1, 2, 3}, []int{5})).To(BeFalse())
+			Expect(set.Slice()).To(ConsistOf(1, 2))
+			Expect(set.CompareAndSwap([]int{2, 1, 1}, []int{2, 3})).To(BeTrue())
+			Expect(set.Slice()).To(ConsistOf(2, 3))
+		},
+		Entry("Set", func() collection.Set[int] { return collection.NewSet(1, 2) }),
+		Entry("LinkedSet", func() collection.Set[int] { return collection.NewLinkedSet(1, 2) }),
+		Entry("BitmapSet", func() collection.Set[int] { return collection.NewBitmapSet(1, 2) }),
+	)
+	It("applies the changes of a transaction", func() {
+		set := collection.NewSet(1, 2)
+		err := set.Update(func(tx collection.SetTx[int]) error {
+			tx.Remove(1)
+			tx.Add(3, 4)
+			Expect(tx.Contains(1)).To(BeFalse())
+			Expect(tx.Contains(3)).To(BeTrue())
+			Expect(tx.Length()).To(Equal(3))
+			Expect(tx.Slice()).To(ConsistOf(2, 3, 4))
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(set.Slice()).To(ConsistOf(2, 3, 4))
+	})
+	It("rolls back the changes if fn fails", func() {
+		failed := errors.New("failed")
+		set := collection.NewLinkedSet(1, 2)
+		err := set.Update(func(tx collection.SetTx[int]) error {
+			tx.Remove(1)
+			tx.Add(3)
+			return failed
+		})
+		Expect(errors.Is(err, failed)).To(BeTrue())
+		Expect(set.Slice()).To(Equal([]int{1, 2}))
+	})
+	It("reports only the net changes to subscribers", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		set := collection.NewSet(1)
+		events := set.Subscribe(ctx, collection.SubscribeOptions{BufferSize: 10})
+		Expect(set.Update(func(tx collection.SetTx[int]) error {
+			tx.Remove(1)
+			tx.Add(1, 2)
+			tx.Add(3)
+			tx.Remove(3)
+			return nil
+		})).To(Succeed())
+		Expect(<-events).To(Equal(collection.SetEvent[int]{
+			Type:     collection.SetEventAdded,
+			Elements: []int{2},
+		}))
+		Consistently(events).ShouldNot(Receive())
+	})
+	It("adds an element only once with concurrent AddIfAbsent", func() {
+		set := collection.NewSet[int]()
+		var wg sync.WaitGroup
+		var mux sync.Mutex
+		added := 0
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if set.AddIfAbsent(1) {
+					mux.Lock()
+					added++
+					mux.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		Expect(added).To(Equal(1))
+	})
+	DescribeTable("Replace changes the value of an equal element",
+		func(newSet func() keyedUserSet) {
+			set := newSet()
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			events := set.Subscribe(ctx, collection.SubscribeOptions{BufferSize: 10})
+			Expect(set.Replace(keyedUser{ID: 1}, keyedUser{ID: 1, Name: "b"})).To(BeTrue())
+			Expect(set.Slice()).To(Equal([]keyedUser{{ID: 1, Name: "b"}}))
+			Expect(<-events).To(Equal(collection.SetEvent[keyedUser]{
+				Type:     collection.SetEventRemoved,
+				Elements: []keyedUser{{ID: 1, Name: "a"}},
+			}))
+			Expect(<-events).To(Equal(collection.SetEvent[keyedUser]{
+				Type:     collection.SetEventAdded,
+				Elements: []keyedUser{{ID: 1, Name: "b"}},
+			}))
+		},
+		Entry("SetHashCode", func() keyedUserSet {
+			return collection.NewSetHashCode(keyedUser{ID: 1, Name: "a"})
+		}),
+		Entry("SetEqual", func() keyedUserSet {
+			return collection.NewSetEqual(keyedUser{ID: 1, Name: "a"})
+		}),
+	)
+	It("does not report elements added again with the same value", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		set := collection.NewSetHashCode(keyedUser{ID: 1, Name: "a"})
+		events := set.Subscribe(ctx, collection.SubscribeOptions{BufferSize: 10})
+		expected := []keyedUser{{ID: 1, Name: "a"}}
+		Expect(set.CompareAndSwap(expected, expected)).To(BeTrue())
+		Consistently(events).ShouldNot(Receive())
+	})
+	It("works with SetHashCode", func() {
+		set := collection.NewSetHashCode(User{Firstname: "a"})
+		Expect(set.AddIfAbsent(User{Firstname: "a"})).To(BeFalse())
+		Expect(set.Replace(User{Firstname: "a"}, User{Firstname: "b"})).To(BeTrue())
+		Expect(set.CompareAndSwap([]User{{Firstname: "b"}}, nil)).To(BeTrue())
+		Expect(set.Length()).To(Equal(0))
+	})
+	It("works with SetEqual", func() {
+		set := collection.NewSetEqual(User{Firstname: "a"})
+		Expect(set.AddIfAbsent(User{Firstname: "a"})).To(BeFalse())
+		Expect(set.RemoveIfPresent(User{Firstname: "a"})).To(BeTrue())
+		err := set.Update(func(tx collection.SetTx[User]) error {
+			tx.Add(User{Firstname: "b"}, User{Firstname: "c"})
+			tx.Remove(User{Firstname: "c"})
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(set.Slice()).To(Equal([]User{{Firstname: "b"}}))
+	})
+	It("logs the changes of a PersistentSet", func() {
+		ctx := context.Background()
+		path := filepath.Join(GinkgoT().TempDir(), "set.log")
+		options := collection.PersistentSetOptions[string]{}
+		set, err := collection.NewPersistentSet(ctx, path, options)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(set.AddIfAbsent("a")).To(BeTrue())
+		Expect(set.Replace("a", "b")).To(BeTrue())
+		Expect(set.Update(func(tx collection.SetTx[string]) error {
+			tx.Add("c")
+			return errors.New("failed")
+		})).NotTo(Succeed())
+		Expect(set.Close()).To(Succeed())
+
+		reopened, err := collection.NewPersistentSet(ctx, path, options)
+		Expect(err).NotTo(HaveOccurred())
+		defer reopened.Close()
+		Expect(reopened.Strings()).To(Equal([]string{"b"}))
+	})
+})
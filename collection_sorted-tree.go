@@ -0,0 +1,306 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+// sortedTree is an AVL tree ordered by compare. Every node tracks the size of its subtree
+// so rank queries run in O(log n). It is not thread-safe.
+type sortedTree[T any] struct {
+	compare func(a, b T) int
+	root    *sortedTreeNode[T]
+}
+
+type sortedTreeNode[T any] struct {
+	value  T
+	left   *sortedTreeNode[T]
+	right  *sortedTreeNode[T]
+	height int
+	size   int
+}
+
+func (n *sortedTreeNode[T]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *sortedTreeNode[T]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+func (n *sortedTreeNode[T]) update() {
+	n.height = 1 + max(n.left.getHeight(), n.right.getHeight())
+	n.size = 1 + n.left.getSize() + n.right.getSize()
+}
+
+func (n *sortedTreeNode[T]) balanceFactor() int {
+	return n.left.getHeight() - n.right.getHeight()
+}
+
+func (n *sortedTreeNode[T]) rotateRight() *sortedTreeNode[T] {
+	left := n.left
+	n.left = left.right
+	left.right = n
+	n.update()
+	left.update()
+	return left
+}
+
+func (n *sortedTreeNode[T]) rotateLeft() *sortedTreeNode[T] {
+	right := n.right
+	n.right = right.left
+	right.left = n
+	n.update()
+	right.update()
+	return right
+}
+
+func (n *sortedTreeNode[T]) rebalance() *sortedTreeNode[T] {
+	n.update()
+	switch balance := n.balanceFactor(); {
+	case balance > 1:
+		if n.left.balanceFactor() < 0 {
+			n.left = n.left.rotateLeft()
+		}
+		return n.rotateRight()
+	case balance < -1:
+		if n.right.balanceFactor() > 0 {
+			n.right = n.right.rotateRight()
+		}
+		return n.rotateLeft()
+	default:
+		return n
+	}
+}
+
+func (n *sortedTreeNode[T]) clone() *sortedTreeNode[T] {
+	if n == nil {
+		return nil
+	}
+	return &sortedTreeNode[T]{
+		value:  n.value,
+		left:   n.left.clone(),
+		right:  n.right.clone(),
+		height: n.height,
+		size:   n.size,
+	}
+}
+
+func (t *sortedTree[T]) length() int {
+	return t.root.getSize()
+}
+
+// insert adds value and reports whether it was not present before.
+func (t *sortedTree[T]) insert(value T) bool {
+	var inserted bool
+	t.root = t.insertNode(t.root, value, &inserted)
+	return inserted
+}
+
+func (t *sortedTree[T]) insertNode(
+	node *sortedTreeNode[T],
+	value T,
+	inserted *bool,
+) *sortedTreeNode[T] {
+	if node == nil {
+		*inserted = true
+		return &sortedTreeNode[T]{value: value, height: 1, size: 1}
+	}
+	switch c := t.compare(value, node.value); {
+	case c < 0:
+		node.left = t.insertNode(node.left, value, inserted)
+	case c > 0:
+		node.right = t.insertNode(node.right, value, inserted)
+	default:
+		return node
+	}
+	return node.rebalance()
+}
+
+// delete removes value and reports whether it was present.
+func (t *sortedTree[T]) delete(value T) bool {
+	var deleted bool
+	t.root = t.deleteNode(t.root, value, &deleted)
+	return deleted
+}
+
+func (t *sortedTree[T]) deleteNode(
+	node *sortedTreeNode[T],
+	value T,
+	deleted *bool,
+) *sortedTreeNode[T] {
+	if node == nil {
+		return nil
+	}
+	switch c := t.compare(value, node.value); {
+	case c < 0:
+		node.left = t.deleteNode(node.left, value, deleted)
+	case c > 0:
+		node.right = t.deleteNode(node.right, value, deleted)
+	default:
+		*deleted = true
+		if node.left == nil {
+			return node.right
+		}
+		if node.right == nil {
+			return node.left
+		}
+		successor := node.right
+		for successor.left != nil {
+			successor = successor.left
+		}
+		node.value = successor.value
+		node.right = t.deleteNode(node.right, successor.value, new(bool))
+	}
+	return node.rebalance()
+}
+
+func (t *sortedTree[T]) contains(value T) bool {
+	node := t.root
+	for node != nil {
+		switch c := t.compare(value, node.value); {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			node = node.right
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+func (t *sortedTree[T]) min() (T, bool) {
+	var zero T
+	if t.root == nil {
+		return zero, false
+	}
+	node := t.root
+	for node.left != nil {
+		node = node.left
+	}
+	return node.value, true
+}
+
+func (t *sortedTree[T]) max() (T, bool) {
+	var zero T
+	if t.root == nil {
+		return zero, false
+	}
+	node := t.root
+	for node.right != nil {
+		node = node.right
+	}
+	return node.value, true
+}
+
+// floor returns the greatest value less than or equal to value.
+func (t *sortedTree[T]) floor(value T) (T, bool) {
+	var result T
+	var found bool
+	node := t.root
+	for node != nil {
+		switch c := t.compare(value, node.value); {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			result, found = node.value, true
+			node = node.right
+		default:
+			return node.value, true
+		}
+	}
+	return result, found
+}
+
+// ceiling returns the smallest value greater than or equal to value.
+func (t *sortedTree[T]) ceiling(value T) (T, bool) {
+	var result T
+	var found bool
+	node := t.root
+	for node != nil {
+		switch c := t.compare(value, node.value); {
+		case c < 0:
+			result, found = node.value, true
+			node = node.left
+		case c > 0:
+			node = node.right
+		default:
+			return node.value, true
+		}
+	}
+	return result, found
+}
+
+// rank returns the number of values less than value.
+func (t *sortedTree[T]) rank(value T) int {
+	result := 0
+	node := t.root
+	for node != nil {
+		switch c := t.compare(value, node.value); {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			result += node.left.getSize() + 1
+			node = node.right
+		default:
+			return result + node.left.getSize()
+		}
+	}
+	return result
+}
+
+// ascend calls fn for each value in ascending order until fn returns false.
+func (t *sortedTree[T]) ascend(fn func(value T) bool) {
+	t.ascendNode(t.root, fn)
+}
+
+func (t *sortedTree[T]) ascendNode(node *sortedTreeNode[T], fn func(value T) bool) bool {
+	if node == nil {
+		return true
+	}
+	return t.ascendNode(node.left, fn) && fn(node.value) && t.ascendNode(node.right, fn)
+}
+
+// ascendRange calls fn for each value in [from, to) in ascending order until fn returns false.
+func (t *sortedTree[T]) ascendRange(from T, to T, fn func(value T) bool) {
+	t.ascendRangeNode(t.root, from, to, fn)
+}
+
+func (t *sortedTree[T]) ascendRangeNode(
+	node *sortedTreeNode[T],
+	from T,
+	to T,
+	fn func(value T) bool,
+) bool {
+	if node == nil {
+		return true
+	}
+	aboveFrom := t.compare(node.value, from) >= 0
+	belowTo := t.compare(node.value, to) < 0
+	if aboveFrom && !t.ascendRangeNode(node.left, from, to, fn) {
+		return false
+	}
+	if aboveFrom && belowTo && !fn(node.value) {
+		return false
+	}
+	if belowTo {
+		return t.ascendRangeNode(node.right, from, to, fn)
+	}
+	return true
+}
+
+// values returns all values in ascending order.
+func (t *sortedTree[T]) values() []T {
+	result := make([]T, 0, t.length())
+	t.ascend(func(value T) bool {
+		result = append(result, value)
+		return true
+	})
+	return result
+}
@@ -0,0 +1,294 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/bborbe/errors"
+)
+
+// SyncMap represents a thread-safe map from keys to values, the companion of Set.
+// It is named SyncMap because Map is the slice transformation of this package.
+type SyncMap[K comparable, V any] interface {
+	// Get returns the value stored for key and whether it was found.
+	Get(key K) (V, bool)
+	// Set stores value for key, replacing any existing value.
+	Set(key K, value V)
+	// Delete removes keys from the map. Missing keys are ignored.
+	Delete(keys ...K)
+	// GetOrSet returns the existing value for key if present. Otherwise it stores and
+	// returns value. The loaded result is true if the value was already present.
+	GetOrSet(key K, value V) (actual V, loaded bool)
+	// Update atomically replaces the value of key with the result of fn and returns it.
+	// fn receives the current value and whether it was found, and is called with the map
+	// locked, so it must not call methods of the map.
+	Update(key K, fn func(value V, found bool) V) V
+	// Keys returns a new Set containing all keys.
+	Keys() Set[K]
+	// Values returns all values in arbitrary order.
+	Values() []V
+	// Length returns the number of entries in the map.
+	Length() int
+	// Each calls fn for each entry in the map. Iteration stops on first error.
+	// The order of iteration is arbitrary and not guaranteed to be consistent.
+	// fn may access and change the map; changes are not reflected in the iteration.
+	Each(ctx context.Context, fn func(ctx context.Context, key K, value V) error) error
+	// Clone returns a new SyncMap containing all entries of the current map.
+	// The returned map is a shallow copy - modifications to it won't affect the original.
+	Clone() SyncMap[K, V]
+	// Without returns a new SyncMap containing all entries of the current map
+	// except those with the given keys. The original map is not modified.
+	Without(keys ...K) SyncMap[K, V]
+	// String returns a human-readable representation in the form "SyncMap[a:1, b:2]".
+	String() string
+	// UnmarshalText parses comma-separated key=value pairs into the map.
+	// It implements encoding.TextUnmarshaler for automatic parsing with argument packages.
+	UnmarshalText(text []byte) error
+	// MarshalText converts the map to comma-separated key=value pairs ordered by key.
+	// It implements encoding.TextMarshaler for automatic serialization.
+	MarshalText() ([]byte, error)
+	// UnmarshalJSON replaces the content with a JSON object mapping keys to values.
+	UnmarshalJSON(data []byte) error
+	// MarshalJSON writes the map as a JSON object mapping keys to values.
+	MarshalJSON() ([]byte, error)
+}
+
+// NewSyncMap creates a new empty thread-safe map.
+//
+// Example:
+//
+//	counts := collection.NewSyncMap[string, int]()
+//	counts.Update("a", func(value int, found bool) int { return value + 1 })
+func NewSyncMap[K comparable, V any]() SyncMap[K, V] {
+	return newSyncMap[K, V](nil)
+}
+
+// NewSyncMapFromMap creates a new thread-safe map containing a copy of the entries of m.
+//
+// Example:
+//
+//	limits := collection.NewSyncMapFromMap(map[string]int{"cpu": 2, "memory": 512})
+func NewSyncMapFromMap[K comparable, V any](m map[K]V) SyncMap[K, V] {
+	return newSyncMap(m)
+}
+
+func newSyncMap[K comparable, V any](m map[K]V) *syncMap[K, V] {
+	data := make(map[K]V, len(m))
+	maps.Copy(data, m)
+	return &syncMap[K, V]{
+		data: data,
+	}
+}
+
+type syncMap[K comparable, V any] struct {
+	mux  sync.Mutex
+	data map[K]V
+}
+
+func (m *syncMap[K, V]) Get(key K) (V, bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	value, found := m.data[key]
+	return value, found
+}
+
+func (m *syncMap[K, V]) Set(key K, value V) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.data[key] = value
+}
+
+func (m *syncMap[K, V]) Delete(keys ...K) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	for _, key := range keys {
+		delete(m.data, key)
+	}
+}
+
+func (m *syncMap[K, V]) GetOrSet(key K, value V) (V, bool) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	if actual, found := m.data[key]; found {
+		return actual, true
+	}
+	m.data[key] = value
+	return value, false
+}
+
+func (m *syncMap[K, V]) Update(key K, fn func(value V, found bool) V) V {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	value, found := m.data[key]
+	value = fn(value, found)
+	m.data[key] = value
+	return value
+}
+
+func (m *syncMap[K, V]) Keys() Set[K] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return NewSet(slices.Collect(maps.Keys(m.data))...)
+}
+
+func (m *syncMap[K, V]) Values() []V {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return slices.Collect(maps.Values(m.data))
+}
+
+func (m *syncMap[K, V]) Length() int {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return len(m.data)
+}
+
+// Each calls fn for each entry in the map. Iteration stops on first error.
+// The order of iteration is arbitrary and not guaranteed to be consistent.
+// fn may access and change the map; changes are not reflected in the iteration.
+func (m *syncMap[K, V]) Each(
+	ctx context.Context,
+	fn func(ctx context.Context, key K, value V) error,
+) error {
+	m.mux.Lock()
+	keys := make([]K, 0, len(m.data))
+	values := make([]V, 0, len(m.data))
+	for key, value := range m.data {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	m.mux.Unlock()
+
+	return eachPair(ctx, keys, values, fn)
+}
+
+// Clone returns a new SyncMap containing all entries of the current map.
+// The returned map is a shallow copy - modifications to it won't affect the original.
+func (m *syncMap[K, V]) Clone() SyncMap[K, V] {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return newSyncMap(m.data)
+}
+
+// Without returns a new SyncMap containing all entries of the current map
+// except those with the given keys. The original map is not modified.
+func (m *syncMap[K, V]) Without(keys ...K) SyncMap[K, V] {
+	result := m.Clone()
+	result.Delete(keys...)
+	return result
+}
+
+// String returns a human-readable string representation of the map.
+// Format: "SyncMap[key1:value1, key2:value2, ...]" sorted by the string representation.
+func (m *syncMap[K, V]) String() string {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	result := make([]string, 0, len(m.data))
+	for key, value := range m.data {
+		result = append(result, elementToString(key)+":"+elementToString(value))
+	}
+	sort.Strings(result)
+	return formatSetString("SyncMap[", result)
+}
+
+// MarshalText implements encoding.TextMarshaler for SyncMap.
+// Entries are written as key=value pairs in natural order of the keys for types based on
+// string, int, uint or float, or sorted by the string representation of the keys.
+func (m *syncMap[K, V]) MarshalText() ([]byte, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	keys := slices.Collect(maps.Keys(m.data))
+	if compare := naturalCompare[K](); compare != nil {
+		slices.SortFunc(keys, compare)
+	} else {
+		sort.Slice(keys, func(i, j int) bool {
+			return elementToString(keys[i]) < elementToString(keys[j])
+		})
+	}
+	result := make([]string, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, elementToString(key)+"="+elementToString(m.data[key]))
+	}
+	return []byte(strings.Join(result, ",")), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler for SyncMap.
+// This allows SyncMap[K, V] to be parsed from text like "cpu=2,memory=512"
+// when used with github.com/bborbe/argument.
+// Keys and values are converted like the elements of Set.UnmarshalText.
+// The map is left unchanged if a pair can't be parsed.
+func (m *syncMap[K, V]) UnmarshalText(text []byte) error {
+	data := make(map[K]V)
+	for _, part := range splitText(string(text)) {
+		keyText, valueText, found := strings.Cut(part, "=")
+		if !found {
+			return errors.Errorf(context.Background(), "parse %q failed: missing '='", part)
+		}
+		key, err := parseTextElement[K](strings.TrimSpace(keyText))
+		if err != nil {
+			return err
+		}
+		value, err := parseTextElement[V](strings.TrimSpace(valueText))
+		if err != nil {
+			return err
+		}
+		data[key] = value
+	}
+
+	m.replace(data)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler for SyncMap.
+// It serializes the map as a JSON object, so K must be a string or integer type
+// or implement encoding.TextMarshaler.
+func (m *syncMap[K, V]) MarshalJSON() ([]byte, error) {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	return json.Marshal(m.data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler for SyncMap.
+// It replaces the content of the map with the entries of a JSON object.
+func (m *syncMap[K, V]) UnmarshalJSON(data []byte) error {
+	var entries map[K]V
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+
+	m.replace(entries)
+	return nil
+}
+
+// replace swaps the content of the map for data.
+func (m *syncMap[K, V]) replace(data map[K]V) {
+	if data == nil {
+		data = make(map[K]V)
+	}
+
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	m.data = data
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collection_test
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+)
+
+var _ = Describe("SyncMap", func() {
+	It("stores and deletes values", func() {
+		m := collection.NewSyncMap[string, int]()
+		m.Set("a", 1)
+		m.Set("b", 2)
+		Expect(mustFind(m.Get("a"))).To(Equal(1))
+		_, found := m.Get("c")
+		Expect(found).To(BeFalse())
+
+		m.Delete("a", "c")
+		Expect(m.Length()).To(Equal(1))
+		Expect(m.Keys().Slice()).To(Equal([]string{"b"}))
+		Expect(m.Values()).To(Equal([]int{2}))
+	})
+	It("copies the initial map", func() {
+		initial := map[string]int{"a": 1}
+		m := collection.NewSyncMapFromMap(initial)
+		initial["b"] = 2
+		Expect(m.Length()).To(Equal(1))
+	})
+	It("returns existing values from GetOrSet", func() {
+		m := collection.NewSyncMapFromMap(map[string]int{"a": 1})
+		actual, loaded := m.GetOrSet("a", 5)
+		Expect(actual).To(Equal(1))
+		Expect(loaded).To(BeTrue())
+		actual, loaded = m.GetOrSet("b", 5)
+		Expect(actual).To(Equal(5))
+		Expect(loaded).To(BeFalse())
+	})
+	It("updates values atomically", func() {
+		m := collection.NewSyncMap[string, int]()
+		var wg sync.WaitGroup
+		for i := 0; i < 100; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				m.Update("counter", func(value int, found bool) int {
+					return value + 1
+				})
+			}()
+		}
+		wg.Wait()
+		Expect(mustFind(m.Get("counter"))).To(Equal(100))
+	})
+	It("iterates all entries", func() {
+		m := collection.NewSyncMapFromMap(map[string]int{"a": 1, "b": 2})
+		var keys []string
+		err := m.Each(context.Background(), func(ctx context.Context, key string, value int) error {
+			keys = append(keys, key)
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		sort.Strings(keys)
+		Expect(keys).To(Equal([]string{"a", "b"}))
+	})
+	It("clones without affecting the original", func() {
+		m := collection.NewSyncMapFromMap(map[string]int{"a": 1, "b": 2})
+		without := m.Without("a")
+		without.Set("c", 3)
+		Expect(without.String()).To(Equal("SyncMap[b:2, c:3]"))
+		Expect(m.String()).To(Equal("SyncMap[a:1, b:2]"))
+	})
+	It("round-trips json", func() {
+		m := collection.NewSyncMapFromMap(map[string]int{"b": 2, "a": 1})
+		data, err := json.Marshal(m)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(data)).To(Equal(`{"a":1,"b":2}`))
+
+		decoded := collection.NewSyncMapFromMap(map[string]int{"c": 3})
+		Expect(json.Unmarshal(data, decoded)).To(Succeed())
+		Expect(decoded.String()).To(Equal("SyncMap[a:1, b:2]"))
+	})
+	It("round-trips text", func() {
+		m := collection.NewSyncMapFromMap(map[int]time.Duration{10: time.Second, 9: time.Minute})
+		text, err := m.MarshalText()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(text)).To(Equal("9=1m0s,10=1s"))
+
+		decoded := collection.NewSyncMap[int, time.Duration]()
+		Expect(decoded.UnmarshalText([]byte("1 = 2s, 3=1h"))).To(Succeed())
+		Expect(decoded.Length()).To(Equal(2))
+		Expect(mustFind(decoded.Get(3))).To(Equal(time.Hour))
+	})
+	It("rejects invalid text", func() {
+		m := collection.NewSyncMapFromMap(map[string]int{"a": 1})
+		Expect(m.UnmarshalText([]byte("b"))).NotTo(Succeed())
+		Expect(m.UnmarshalText([]byte("b=x"))).NotTo(Succeed())
+		Expect(m.String()).To(Equal("SyncMap[a:1]"))
+	})
+})
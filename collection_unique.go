@@ -4,6 +4,8 @@
 
 package collection
 
+import "iter"
+
 // Unique returns a new slice containing only the unique elements from the input slice.
 // The order of the first occurrence of each element is preserved.
 func Unique[T comparable](list []T) []T {
@@ -18,3 +20,20 @@ func Unique[T comparable](list []T) []T {
 	}
 	return result
 }
+
+// UniqueSeq returns an iterator over the unique elements of seq.
+// The order of the first occurrence of each element is preserved.
+func UniqueSeq[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		m := map[T]bool{}
+		for ee := range seq {
+			if m[ee] {
+				continue
+			}
+			m[ee] = true
+			if !yield(ee) {
+				return
+			}
+		}
+	}
+}
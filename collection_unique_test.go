@@ -5,6 +5,8 @@
 package collection_test
 
 import (
+	"slices"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
@@ -78,3 +80,10 @@ var _ = Describe("Unique with custom types", func() {
 		Expect(result).To(Equal(expected))
 	})
 })
+
+var _ = Describe("UniqueSeq", func() {
+	It("yields unique elements in order of first occurrence", func() {
+		result := collection.UniqueSeq(slices.Values([]string{"b", "a", "b", "c", "a"}))
+		Expect(slices.Collect(result)).To(Equal([]string{"b", "a", "c"}))
+	})
+})
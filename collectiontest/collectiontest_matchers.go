@@ -0,0 +1,193 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collectiontest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bborbe/errors"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+
+	"github.com/bborbe/collection"
+)
+
+// set is implemented by collection.Set, collection.SetHashCode and collection.SetEqual.
+type set[T any] interface {
+	Contains(element T) bool
+	Length() int
+	Slice() []T
+}
+
+// ContainElementsInSet succeeds if the actual set contains all elements.
+// It works with Set, SetHashCode and SetEqual and uses their own Contains.
+func ContainElementsInSet[T any](elements ...T) types.GomegaMatcher {
+	return &setMatcher[T]{
+		message:  "to contain elements",
+		expected: elements,
+		match: func(actual set[T]) bool {
+			return containsAll(actual, elements)
+		},
+	}
+}
+
+// HaveSetLength succeeds if the actual set has length elements.
+// It works with every type with a Length() int method.
+func HaveSetLength(length int) types.GomegaMatcher {
+	return &haveSetLengthMatcher{
+		length: length,
+	}
+}
+
+// EqualSet succeeds if the actual Set contains exactly elements, in any order.
+func EqualSet[T comparable](elements ...T) types.GomegaMatcher {
+	return equalSet(elements, equalComparable[T])
+}
+
+// BeSubsetOf succeeds if all elements of the actual Set are in elements.
+func BeSubsetOf[T comparable](elements ...T) types.GomegaMatcher {
+	return beSubsetOf(elements, equalComparable[T])
+}
+
+// EqualSetHashCode succeeds if the actual SetHashCode contains exactly elements,
+// in any order. Elements are compared by their hash code.
+func EqualSetHashCode[T collection.HasHashCode](elements ...T) types.GomegaMatcher {
+	return equalSet(elements, equalHashCode[T])
+}
+
+// BeSubsetOfSetHashCode succeeds if all elements of the actual SetHashCode are in elements.
+// Elements are compared by their hash code.
+func BeSubsetOfSetHashCode[T collection.HasHashCode](elements ...T) types.GomegaMatcher {
+	return beSubsetOf(elements, equalHashCode[T])
+}
+
+// EqualSetEqual succeeds if the actual SetEqual contains exactly elements, in any order.
+// Elements are compared with their Equal method.
+func EqualSetEqual[T collection.HasEqual[T]](elements ...T) types.GomegaMatcher {
+	return equalSet(elements, equalEqual[T])
+}
+
+// BeSubsetOfSetEqual succeeds if all elements of the actual SetEqual are in elements.
+// Elements are compared with their Equal method.
+func BeSubsetOfSetEqual[T collection.HasEqual[T]](elements ...T) types.GomegaMatcher {
+	return beSubsetOf(elements, equalEqual[T])
+}
+
+func equalComparable[T comparable](a, b T) bool {
+	return a == b
+}
+
+func equalHashCode[T collection.HasHashCode](a, b T) bool {
+	return a.HashCode() == b.HashCode()
+}
+
+func equalEqual[T collection.HasEqual[T]](a, b T) bool {
+	return a.Equal(b)
+}
+
+func equalSet[T any](elements []T, equal func(a, b T) bool) types.GomegaMatcher {
+	return &setMatcher[T]{
+		message:  "to equal set",
+		expected: elements,
+		match: func(actual set[T]) bool {
+			return containsAll(actual, elements) && isSubset(actual.Slice(), elements, equal)
+		},
+	}
+}
+
+func beSubsetOf[T any](elements []T, equal func(a, b T) bool) types.GomegaMatcher {
+	return &setMatcher[T]{
+		message:  "to be subset of",
+		expected: elements,
+		match: func(actual set[T]) bool {
+			return isSubset(actual.Slice(), elements, equal)
+		},
+	}
+}
+
+// containsAll reports whether actual contains all elements.
+func containsAll[T any](actual set[T], elements []T) bool {
+	for _, element := range elements {
+		if !actual.Contains(element) {
+			return false
+		}
+	}
+	return true
+}
+
+// isSubset reports whether every element of actual is equal to an element of elements.
+func isSubset[T any](actual []T, elements []T, equal func(a, b T) bool) bool {
+	for _, a := range actual {
+		found := false
+		for _, e := range elements {
+			if equal(a, e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// setMatcher matches sets with match and reports their elements on failure.
+type setMatcher[T any] struct {
+	message  string
+	expected []T
+	match    func(actual set[T]) bool
+}
+
+func (m *setMatcher[T]) Match(actual interface{}) (bool, error) {
+	s, ok := actual.(set[T])
+	if !ok {
+		return false, errors.Errorf(
+			context.Background(),
+			"expected a set of %T, got %T",
+			*new(T),
+			actual,
+		)
+	}
+	return m.match(s), nil
+}
+
+func (m *setMatcher[T]) FailureMessage(actual interface{}) string {
+	return format.Message(describeSet(actual), m.message, m.expected)
+}
+
+func (m *setMatcher[T]) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(describeSet(actual), "not "+m.message, m.expected)
+}
+
+// haveSetLengthMatcher matches the length of sets.
+type haveSetLengthMatcher struct {
+	length int
+}
+
+func (m *haveSetLengthMatcher) Match(actual interface{}) (bool, error) {
+	s, ok := actual.(interface{ Length() int })
+	if !ok {
+		return false, errors.Errorf(context.Background(), "expected a set, got %T", actual)
+	}
+	return s.Length() == m.length, nil
+}
+
+func (m *haveSetLengthMatcher) FailureMessage(actual interface{}) string {
+	return format.Message(describeSet(actual), "to have length", m.length)
+}
+
+func (m *haveSetLengthMatcher) NegatedFailureMessage(actual interface{}) string {
+	return format.Message(describeSet(actual), "not to have length", m.length)
+}
+
+// describeSet returns the string representation of sets instead of their internal fields.
+func describeSet(actual interface{}) interface{} {
+	if s, ok := actual.(fmt.Stringer); ok {
+		return s.String()
+	}
+	return actual
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package collectiontest_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/bborbe/collection"
+	"github.com/bborbe/collection/collectiontest"
+	"github.com/bborbe/collection/mocks"
+)
+
+type user struct {
+	Name string
+}
+
+func (u user) HashCode() string {
+	return strings.ToLower(u.Name)
+}
+
+func (u user) Equal(other user) bool {
+	return strings.EqualFold(u.Name, other.Name)
+}
+
+var _ = Describe("Matchers", func() {
+	It("matches sets independent of order", func() {
+		set := collection.NewSet(1, 2, 3)
+		Expect(set).To(collectiontest.EqualSet(3, 1, 2))
+		Expect(set).NotTo(collectiontest.EqualSet(1, 2))
+		Expect(set).NotTo(collectiontest.EqualSet(1, 2, 3, 4))
+		Expect(collection.NewSet[int]()).To(collectiontest.EqualSet[int]())
+	})
+	It("matches contained elements", func() {
+		set := collection.NewSet("a", "b")
+		Expect(set).To(collectiontest.ContainElementsInSet("b"))
+		Expect(set).NotTo(collectiontest.ContainElementsInSet("a", "c"))
+	})
+	It("matches subsets", func() {
+		set := collection.NewSet(1, 2)
+		Expect(set).To(collectiontest.BeSubsetOf(1, 2, 3))
+		Expect(set).NotTo(collectiontest.BeSubsetOf(1))
+	})
+	It("matches the length", func() {
+		Expect(collection.NewSet(1, 2)).To(collectiontest.HaveSetLength(2))
+		Expect(collection.NewSetHashCode(user{Name: "a"})).To(collectiontest.HaveSetLength(1))
+	})
+	It("compares SetHashCode elements by hash code", func() {
+		set := collection.NewSetHashCode(user{Name: "Alice"}, user{Name: "Bob"})
+		Expect(set).To(collectiontest.EqualSetHashCode(user{Name: "bob"}, user{Name: "alice"}))
+		Expect(set).To(collectiontest.BeSubsetOfSetHashCode(
+			user{Name: "alice"},
+			user{Name: "bob"},
+			user{Name: "carol"},
+		))
+		Expect(set).To(collectiontest.ContainElementsInSet(user{Name: "ALICE"}))
+	})
+	It("compares SetEqual elements with Equal", func() {
+		set := collection.NewSetEqual(user{Name: "Alice"})
+		Expect(set).To(collectiontest.EqualSetEqual(user{Name: "alice"}))
+		Expect(set).NotTo(collectiontest.BeSubsetOfSetEqual(user{Name: "bob"}))
+	})
+	It("fails for values that are not sets", func() {
+		success, err := collectiontest.EqualSet(1).Match([]int{1})
+		Expect(err).To(HaveOccurred())
+		Expect(success).To(BeFalse())
+		_, err = collectiontest.HaveSetLength(1).Match(42)
+		Expect(err).To(HaveOccurred())
+	})
+	It("describes the set on failure", func() {
+		matcher := collectiontest.EqualSet(1)
+		Expect(matcher.FailureMessage(collection.NewSet(2))).To(ContainSubstring("Set[2]"))
+	})
+	It("matches counterfeiter fakes", func() {
+		fake := &mocks.CollectionSet[int]{}
+		fake.SliceReturns([]int{1, 2})
+		fake.LengthReturns(2)
+		fake.ContainsStub = func(element int) bool {
+			return element == 1 || element == 2
+		}
+		var set collection.Set[int] = fake
+		Expect(set).To(collectiontest.EqualSet(1, 2))
+		Expect(fake.ContainsCallCount()).To(Equal(2))
+		Expect(fake.ContainsArgsForCall(0)).To(Equal(1))
+	})
+})
@@ -0,0 +1,16 @@
+// Copyright (c) 2026 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package collectiontest provides Gomega matchers for the sets of package collection.
+//
+// The matchers compare set contents independent of order, so tests don't need to
+// sort Slice() or use ConsistOf:
+//
+//	Expect(set).To(collectiontest.EqualSet(1, 2, 3))
+//	Expect(set).To(collectiontest.HaveSetLength(3))
+//	Expect(users).To(collectiontest.EqualSetHashCode(alice, bob))
+//
+// Counterfeiter fakes of Set, SetHashCode and SetEqual are in package
+// github.com/bborbe/collection/mocks.
+package collectiontest